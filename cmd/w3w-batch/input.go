@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+)
+
+const (
+	inputLines  = "lines"
+	inputCSV    = "csv"
+	inputNDJSON = "ndjson"
+)
+
+// row is a single input record to be converted
+type row struct {
+	Index  int
+	Source string
+	Input  string
+
+	Words  *w3w.Words
+	Coords *w3w.LatLng
+	Err    error
+}
+
+// reader reads rows from r and passes them to emit in input order, stopping
+// at the first read error. Rows which cannot be parsed are emitted with Err
+// set so they are reported alongside conversion failures.
+type reader func(name string, r io.Reader, emit func(row)) error
+
+func newReader(format string) (reader, error) {
+	switch format {
+	case inputLines, "":
+		return readLines, nil
+	case inputCSV:
+		return readCSV, nil
+	case inputNDJSON:
+		return readNDJSON, nil
+	}
+
+	return nil, fmt.Errorf("unsupported input format %q", format)
+}
+
+func readLines(name string, r io.Reader, emit func(row)) error {
+	s := bufio.NewScanner(r)
+
+	var line int
+	for s.Scan() {
+		line++
+
+		text := strings.TrimSpace(s.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		emit(parseRow(source(name, line), text))
+	}
+
+	return s.Err()
+}
+
+// readCSV reads either a single column of inputs or `lat,lng` pairs.
+// A header row naming `words`, `lat` and `lng` columns selects those columns.
+func readCSV(name string, r io.Reader, emit func(row)) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var (
+		line   int
+		header *csvColumns
+	)
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line++
+
+		if line == 1 {
+			if cols, ok := parseCSVHeader(rec); ok {
+				header = &cols
+				continue
+			}
+		}
+
+		src := source(name, line)
+
+		var input string
+		switch {
+		case header != nil:
+			input = header.input(rec)
+		case len(rec) == 1:
+			input = strings.TrimSpace(rec[0])
+		default:
+			input = strings.TrimSpace(rec[0]) + "," + strings.TrimSpace(rec[1])
+		}
+
+		if input == "" {
+			emit(row{
+				Source: src,
+				Input:  strings.Join(rec, ","),
+				Err:    fmt.Errorf("expected a 3 word address or a lat,lng pair"),
+			})
+			continue
+		}
+
+		emit(parseRow(src, input))
+	}
+}
+
+// csvColumns holds the column indexes named by a CSV header row, -1 marking
+// a column that is not present
+type csvColumns struct {
+	words, lat, lng int
+}
+
+func parseCSVHeader(rec []string) (csvColumns, bool) {
+	cols := csvColumns{-1, -1, -1}
+
+	for i, name := range rec {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "words":
+			cols.words = i
+		case "lat", "latitude":
+			cols.lat = i
+		case "lng", "lon", "longitude":
+			cols.lng = i
+		}
+	}
+
+	found := cols.words >= 0 || (cols.lat >= 0 && cols.lng >= 0)
+
+	return cols, found
+}
+
+// input returns the record's 3 word address, falling back to its coordinates
+func (c csvColumns) input(rec []string) string {
+	field := func(i int) string {
+		if i < 0 || i >= len(rec) {
+			return ""
+		}
+		return strings.TrimSpace(rec[i])
+	}
+
+	if words := field(c.words); words != "" {
+		return words
+	}
+
+	lat, lng := field(c.lat), field(c.lng)
+	if lat == "" || lng == "" {
+		return ""
+	}
+
+	return lat + "," + lng
+}
+
+// ndjsonRecord defines an NDJSON input line, either `{"words": "..."}` or
+// `{"lat": 0, "lng": 0}`
+type ndjsonRecord struct {
+	Words string   `json:"words"`
+	Lat   *float64 `json:"lat"`
+	Lng   *float64 `json:"lng"`
+}
+
+func readNDJSON(name string, r io.Reader, emit func(row)) error {
+	s := bufio.NewScanner(r)
+
+	var line int
+	for s.Scan() {
+		line++
+
+		text := strings.TrimSpace(s.Text())
+		if text == "" {
+			continue
+		}
+
+		src := source(name, line)
+
+		var rec ndjsonRecord
+		if err := json.Unmarshal([]byte(text), &rec); err != nil {
+			emit(row{
+				Source: src,
+				Input:  text,
+				Err:    fmt.Errorf("invalid JSON %w", err),
+			})
+			continue
+		}
+
+		switch {
+		case rec.Words != "":
+			emit(parseRow(src, rec.Words))
+		case rec.Lat != nil && rec.Lng != nil:
+			emit(row{
+				Source: src,
+				Input:  formatCoordinates(*rec.Lat, *rec.Lng),
+				Coords: &w3w.LatLng{Lat: *rec.Lat, Lng: *rec.Lng},
+			})
+		default:
+			emit(row{
+				Source: src,
+				Input:  text,
+				Err:    fmt.Errorf("expected a words field or lat & lng fields"),
+			})
+		}
+	}
+
+	return s.Err()
+}
+
+// parseRow treats input as coordinates when w3w.ParseCoordinates accepts it
+// or it is a comma separated pair, and as a 3 word address otherwise
+func parseRow(src, input string) row {
+	r := row{
+		Source: src,
+		Input:  input,
+	}
+
+	coords, err := w3w.ParseCoordinates(input)
+	if err == nil {
+		r.Coords = &coords
+		return r
+	}
+
+	if !errors.Is(err, w3w.ErrInvalidCoordinates) || len(strings.Split(input, ",")) == 2 {
+		r.Err = errors.Unwrap(err)
+		return r
+	}
+
+	words, err := w3w.ParseWords(input)
+	if err != nil {
+		// the row's input is already reported alongside the reason
+		r.Err = errors.Unwrap(err)
+		return r
+	}
+
+	r.Words = &words
+
+	return r
+}
+
+func source(name string, line int) string {
+	return fmt.Sprintf("%s:%d", name, line)
+}
+
+func formatCoordinates(lat, lng float64) string {
+	return formatFloat(lat) + "," + formatFloat(lng)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
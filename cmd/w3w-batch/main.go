@@ -0,0 +1,296 @@
+// Command w3w-batch converts a stream of coordinates and 3 word addresses.
+//
+// Usage:
+//
+//	w3w-batch [flags] [file ...]
+//
+// Inputs are read from the given files, or stdin when none are given, one per
+// line, as CSV or as NDJSON. Each input is converted in the direction implied
+// by its shape: `lat,lng` pairs to 3 word addresses and 3 word addresses to
+// coordinates. Results are written to stdout in input order as they complete,
+// progress is reported on stderr and the exit status is non-zero when any row
+// fails.
+//
+// The API key is read from the W3W_API_KEY environment variable.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+
+	envAPIKey = "W3W_API_KEY"
+	stdinName = "-"
+
+	defaultWorkers   = 4
+	progressInterval = 250 * time.Millisecond
+	maxReportedRows  = 20
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("w3w-batch", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	inFormat := fs.String("in", inputLines, "input format: lines, csv or ndjson")
+	outFormat := fs.String("out", outputText, "output format: text, json or csv")
+	workers := fs.Int("workers", defaultWorkers, "number of concurrent conversions")
+	language := fs.String("language", "", "language of the returned 3 word addresses")
+	apiURL := fs.String("api-url", "", "override the what3words API URL")
+	quiet := fs.Bool("quiet", false, "do not report progress on stderr")
+
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	if *workers < 1 {
+		fmt.Fprintln(stderr, "workers must be at least 1")
+		return exitUsage
+	}
+
+	read, err := newReader(*inFormat)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	out, err := newWriter(*outFormat, stdout)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	c, err := w3w.New(os.Getenv(envAPIKey))
+	if err != nil {
+		fmt.Fprintf(stderr, "%v: set %s\n", err, envAPIKey)
+		return exitError
+	}
+
+	b := batch{
+		client:  c,
+		workers: *workers,
+		coordOpts: w3w.CoordinateOptions{
+			APIURL: *apiURL,
+		},
+		wordOpts: w3w.WordOptions{
+			APIURL:   *apiURL,
+			Language: *language,
+		},
+	}
+
+	var p *progress
+	if !*quiet {
+		p = &progress{w: stderr}
+	}
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{stdinName}
+	}
+
+	sum, err := b.run(readFiles(files, stdin, read), out, p)
+	if err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return exitError
+	}
+
+	if sum.readErr != nil {
+		fmt.Fprintln(stderr, "Error reading input:", sum.readErr)
+	}
+
+	if len(sum.failed) > 0 {
+		sum.report(stderr)
+	}
+
+	if sum.readErr != nil || len(sum.failed) > 0 {
+		return exitError
+	}
+
+	return exitOK
+}
+
+// readFiles returns a source which reads each file in turn, `-` being stdin
+func readFiles(files []string, stdin io.Reader, read reader) func(emit func(row)) error {
+	return func(emit func(row)) error {
+		for _, name := range files {
+			if name == stdinName {
+				if err := read("stdin", stdin, emit); err != nil {
+					return fmt.Errorf("stdin: %w", err)
+				}
+				continue
+			}
+
+			f, err := os.Open(name)
+			if err != nil {
+				return err
+			}
+
+			err = read(name, f, emit)
+			f.Close()
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// batch converts rows concurrently
+type batch struct {
+	client    *w3w.Client
+	workers   int
+	coordOpts w3w.CoordinateOptions
+	wordOpts  w3w.WordOptions
+}
+
+// summary describes a completed batch run
+type summary struct {
+	total   int
+	failed  []result
+	readErr error
+}
+
+func (s summary) report(w io.Writer) {
+	fmt.Fprintf(w, "%d of %d rows failed:\n", len(s.failed), s.total)
+
+	for i, res := range s.failed {
+		if i == maxReportedRows {
+			fmt.Fprintf(w, "  ... and %d more\n", len(s.failed)-maxReportedRows)
+			break
+		}
+		fmt.Fprintf(w, "  %s %q: %s\n", res.Source, res.Input, errorText(res.Err))
+	}
+}
+
+// run reads rows from source, converts them using the configured number of
+// workers and writes the results to out in input order
+func (b batch) run(source func(emit func(row)) error, out writer, p *progress) (summary, error) {
+	rows := make(chan row, b.workers)
+	results := make(chan result, b.workers)
+
+	var readErr error
+	go func() {
+		defer close(rows)
+
+		var index int
+		readErr = source(func(r row) {
+			r.Index = index
+			index++
+			rows <- r
+		})
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < b.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for r := range rows {
+				results <- b.convert(r)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var (
+		sum      summary
+		writeErr error
+		next     int
+		pending  = map[int]result{}
+	)
+	for res := range results {
+		pending[res.Index] = res
+
+		for {
+			r, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+
+			sum.total++
+			if r.Err != nil {
+				sum.failed = append(sum.failed, r)
+			}
+
+			// keep draining results after a write error so the workers exit
+			if writeErr == nil {
+				writeErr = out.Write(r)
+			}
+		}
+
+		p.update(sum.total, len(sum.failed), false)
+	}
+	p.update(sum.total, len(sum.failed), true)
+
+	if writeErr == nil {
+		writeErr = out.Flush()
+	}
+
+	// results is only closed once the reader has returned
+	sum.readErr = readErr
+
+	return sum, writeErr
+}
+
+func (b batch) convert(r row) result {
+	res := result{row: r}
+	if r.Err != nil {
+		return res
+	}
+
+	if r.Coords != nil {
+		res.Result, res.Err = b.client.GetWords(*r.Coords, b.wordOpts)
+	} else {
+		res.Result, res.Err = b.client.GetCoordinates(*r.Words, b.coordOpts)
+	}
+
+	return res
+}
+
+// progress reports the number of converted rows on a single, rewritten line
+type progress struct {
+	w    io.Writer
+	last time.Time
+}
+
+func (p *progress) update(done, failed int, final bool) {
+	if p == nil {
+		return
+	}
+
+	now := time.Now()
+	if !final && now.Sub(p.last) < progressInterval {
+		return
+	}
+	p.last = now
+
+	fmt.Fprintf(p.w, "\rconverted %d rows, %d failed", done, failed)
+	if final {
+		fmt.Fprintln(p.w)
+	}
+}
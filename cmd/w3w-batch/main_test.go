@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jonnypillar/what3words/internal/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		args  []string
+		stdin string
+
+		expectedCode   int
+		expectedOut    string
+		expectedErrOut string
+	}{
+		{
+			desc:  "given lines of words & coordinates, results written in input order",
+			args:  []string{"-workers", "3"},
+			stdin: "one.two.three\n\n# comment\n51.520847,-0.195521\n///four.five.six\n",
+
+			expectedCode: exitOK,
+			expectedOut: "one.two.three\t51.520847,-0.195521\n" +
+				"51.520847,-0.195521\tone.two.three\n" +
+				"///four.five.six\t51.520847,-0.195521\n",
+		},
+		{
+			desc:  "given a CSV with a header, named columns are converted",
+			args:  []string{"-in", "csv", "-out", "csv"},
+			stdin: "id,lat,lng\n1,51.520847,-0.195521\n",
+
+			expectedCode: exitOK,
+			expectedOut: "source,input,words,lat,lng,country,nearest_place,language,map,error\n" +
+				"stdin:2,\"51.520847,-0.195521\",one.two.three,51.520847,-0.195521,GB,\"Bayswater, London\",en,https://w3w.co/one.two.three,\n",
+		},
+		{
+			desc:  "given NDJSON input, NDJSON results written",
+			args:  []string{"-in", "ndjson", "-out", "json"},
+			stdin: `{"lat": 51.520847, "lng": -0.195521}` + "\n",
+
+			expectedCode: exitOK,
+			expectedOut:  `{"source":"stdin:1","input":"51.520847,-0.195521","result":{"country":"GB","square":{"southwest":{"lat":51.520833,"lng":-0.195543},"northeast":{"lat":51.52086,"lng":-0.195499}},"nearestPlace":"Bayswater, London","coordinates":{"lat":51.520847,"lng":-0.195521},"words":"one.two.three","language":"en","map":"https://w3w.co/one.two.three"}}` + "\n",
+		},
+		{
+			desc:  "given rows which fail, failures reported & non-zero exit code returned",
+			stdin: "one.two\nbad.bad.bad\none.two.three\n",
+
+			expectedCode: exitError,
+			expectedOut: "one.two\terror: invalid number of words provided\n" +
+				"bad.bad.bad\terror: BadWords: Invalid or non-existent 3 word address\n" +
+				"one.two.three\t51.520847,-0.195521\n",
+			expectedErrOut: "2 of 3 rows failed:\n" +
+				"  stdin:1 \"one.two\": invalid number of words provided\n" +
+				"  stdin:2 \"bad.bad.bad\": BadWords: Invalid or non-existent 3 word address\n",
+		},
+		{
+			desc: "given an unsupported input format, usage error returned",
+			args: []string{"-in", "xml"},
+
+			expectedCode:   exitUsage,
+			expectedErrOut: "unsupported input format \"xml\"\n",
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := testServer()
+			defer s.Close()
+
+			os.Setenv(envAPIKey, "foobar")
+			defer os.Unsetenv(envAPIKey)
+
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"-quiet", "-api-url", s.URL}, tt.args...)
+			code := run(args, strings.NewReader(tt.stdin), &stdout, &stderr)
+
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedOut, stdout.String())
+			assert.Equal(t, tt.expectedErrOut, stderr.String())
+		})
+	}
+}
+
+func TestWritersRedactKey(t *testing.T) {
+	res := result{
+		row: row{
+			Source: "stdin:1",
+			Input:  "one.two.three",
+			Err: &url.Error{
+				Op:  "Get",
+				URL: "https://api.what3words.com/v3/convert-to-coordinates?key=foobar&words=one.two.three",
+				Err: errors.New("connection refused"),
+			},
+		},
+	}
+
+	for _, format := range []string{outputText, outputJSON, outputCSV} {
+		var out bytes.Buffer
+		w, err := newWriter(format, &out)
+		assert.Nil(t, err)
+
+		assert.Nil(t, w.Write(res))
+		assert.Nil(t, w.Flush())
+
+		assert.NotContains(t, out.String(), "foobar", format)
+		assert.Contains(t, out.String(), "key=REDACTED", format)
+	}
+
+	var report bytes.Buffer
+	summary{total: 1, failed: []result{res}}.report(&report)
+	assert.NotContains(t, report.String(), "foobar")
+}
+
+// testServer returns the same result for every request, other than for the
+// words bad.bad.bad which return a BadWords error
+func testServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("words") == "bad.bad.bad" {
+			var errResp api.ErrorResponse
+			errResp.Err.Code = "BadWords"
+			errResp.Err.Message = "Invalid or non-existent 3 word address"
+
+			b, _ := json.Marshal(errResp)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(b)
+			return
+		}
+
+		var resp api.Response
+		resp.Country = "GB"
+		resp.Square.Southwest.Lat = 51.520833
+		resp.Square.Southwest.Lng = -0.195543
+		resp.Square.Northeast.Lat = 51.52086
+		resp.Square.Northeast.Lng = -0.195499
+		resp.NearestPlace = "Bayswater, London"
+		resp.Coordinates.Lat = 51.520847
+		resp.Coordinates.Lng = -0.195521
+		resp.Words = "one.two.three"
+		resp.Language = "en"
+		resp.Map = "https://w3w.co/one.two.three"
+
+		b, _ := json.Marshal(resp)
+		w.Write(b)
+	}))
+}
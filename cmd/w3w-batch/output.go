@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/jonnypillar/what3words/internal/api"
+	"github.com/jonnypillar/what3words/pkg/w3w"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputCSV  = "csv"
+)
+
+// result is a converted row
+type result struct {
+	row
+	Result w3w.Result
+}
+
+// writer streams results to the output
+type writer interface {
+	Write(res result) error
+	Flush() error
+}
+
+// errorText returns err's message with any API key in a request URL it
+// quotes redacted, as results are written to files and shared
+func errorText(err error) string {
+	return api.RedactError(err).Error()
+}
+
+func newWriter(format string, w io.Writer) (writer, error) {
+	switch format {
+	case outputText, "":
+		return &textWriter{w: w}, nil
+	case outputJSON:
+		return &jsonWriter{enc: json.NewEncoder(w)}, nil
+	case outputCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported output format %q", format)
+}
+
+// textWriter writes a tab separated `input<TAB>output` line per row, the
+// output being the 3 word address for coordinates and vice versa
+type textWriter struct {
+	w io.Writer
+}
+
+func (t *textWriter) Write(res result) error {
+	var out string
+	switch {
+	case res.Err != nil:
+		out = "error: " + errorText(res.Err)
+	case res.Coords != nil:
+		out = res.Result.Words
+	default:
+		out = formatCoordinates(res.Result.Coordinates.Lat, res.Result.Coordinates.Lng)
+	}
+
+	_, err := fmt.Fprintf(t.w, "%s\t%s\n", res.Input, out)
+
+	return err
+}
+
+func (t *textWriter) Flush() error {
+	return nil
+}
+
+// jsonRecord defines a line of NDJSON output
+type jsonRecord struct {
+	Source string      `json:"source"`
+	Input  string      `json:"input"`
+	Result *w3w.Result `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+type jsonWriter struct {
+	enc *json.Encoder
+}
+
+func (j *jsonWriter) Write(res result) error {
+	rec := jsonRecord{
+		Source: res.Source,
+		Input:  res.Input,
+	}
+
+	if res.Err != nil {
+		rec.Error = errorText(res.Err)
+	} else {
+		rec.Result = &res.Result
+	}
+
+	return j.enc.Encode(rec)
+}
+
+func (j *jsonWriter) Flush() error {
+	return nil
+}
+
+var csvHeader = []string{
+	"source",
+	"input",
+	"words",
+	"lat",
+	"lng",
+	"country",
+	"nearest_place",
+	"language",
+	"map",
+	"error",
+}
+
+type csvWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func (c *csvWriter) Write(res result) error {
+	if !c.wroteHeader {
+		if err := c.w.Write(csvHeader); err != nil {
+			return err
+		}
+		c.wroteHeader = true
+	}
+
+	rec := []string{res.Source, res.Input, "", "", "", "", "", "", "", ""}
+	if res.Err != nil {
+		rec[9] = errorText(res.Err)
+	} else {
+		r := res.Result
+		rec[2] = r.Words
+		rec[3] = formatFloat(r.Coordinates.Lat)
+		rec[4] = formatFloat(r.Coordinates.Lng)
+		rec[5] = r.Country
+		rec[6] = r.NearestPlace
+		rec[7] = r.Language
+		rec[8] = r.Map
+	}
+
+	if err := c.w.Write(rec); err != nil {
+		return err
+	}
+
+	// flush each row so results stream through pipelines as they complete
+	c.w.Flush()
+
+	return c.w.Error()
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+
+	return c.w.Error()
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+)
+
+// cache is a size bounded LRU cache of results which expire after ttl.
+// A zero size or ttl disables caching.
+type cache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List
+	entries map[string]*list.Element
+	now     func() time.Time
+}
+
+type cacheEntry struct {
+	key     string
+	result  w3w.Result
+	expires time.Time
+}
+
+func newCache(size int, ttl time.Duration) *cache {
+	return &cache{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+		now:     time.Now,
+	}
+}
+
+// Get returns the cached result for key, if present and not expired
+func (c *cache) Get(key string) (w3w.Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return w3w.Result{}, false
+	}
+
+	entry := el.Value.(*cacheEntry)
+	if c.now().After(entry.expires) {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		return w3w.Result{}, false
+	}
+
+	c.order.MoveToFront(el)
+
+	return entry.result, true
+}
+
+// Set stores res under key, evicting the least recently used entry when full
+func (c *cache) Set(key string, res w3w.Result) {
+	if c.size <= 0 || c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := c.now().Add(c.ttl)
+
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*cacheEntry)
+		entry.result = res
+		entry.expires = expires
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{
+		key:     key,
+		result:  res,
+		expires: expires,
+	})
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
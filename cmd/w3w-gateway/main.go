@@ -0,0 +1,192 @@
+// Command w3w-gateway is an HTTP gateway in front of the what3words API.
+//
+// It holds the what3words API key and exposes the conversion endpoints to
+// internal services, which authenticate with tokens issued by the gateway:
+//
+//	GET    /v3/convert-to-coordinates?words=filled.count.soap
+//	GET    /v3/convert-to-3wa?coordinates=51.520847,-0.195521&language=en
+//
+// Tokens are passed as a bearer Authorization header or as the `key` query
+// parameter, so existing what3words clients can use the gateway's URL as
+// their API URL. Responses are cached and rate limited per token, and calls
+// to what3words are limited across all tokens. Daily and monthly budgets cap
+// the billable what3words calls, calls beyond them failing with a 429
+// QuotaExceeded error until the next period.
+//
+// The admin endpoints require the admin token set by W3W_GATEWAY_ADMIN_TOKEN:
+//
+//	POST   /admin/tokens        {"name": "billing"} issues a token
+//	GET    /admin/tokens        lists issued tokens
+//	DELETE /admin/tokens/{id}   revokes a token
+//	GET    /metrics             request, cache & upstream counters
+//
+// The what3words API key is read from the W3W_API_KEY environment variable.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wquota"
+)
+
+const (
+	envAPIKey     = "W3W_API_KEY"
+	envAdminToken = "W3W_GATEWAY_ADMIN_TOKEN"
+
+	shutdownTimeout = 10 * time.Second
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	apiURL := flag.String("api-url", "", "override the what3words API URL")
+	tokensFile := flag.String("tokens-file", "", "file to persist issued tokens to; tokens are kept in memory when empty")
+	cacheSize := flag.Int("cache-size", 10000, "maximum number of cached results, 0 disables caching")
+	cacheTTL := flag.Duration("cache-ttl", 24*time.Hour, "how long results are cached for")
+	rate := flag.Float64("rate", 10, "requests per second allowed per token, 0 disables the limit")
+	burst := flag.Int("burst", 20, "burst of requests allowed per token")
+	upstreamRate := flag.Float64("upstream-rate", 0, "what3words requests per second allowed across all tokens, 0 disables the limit")
+	upstreamBurst := flag.Int("upstream-burst", 50, "burst of what3words requests allowed across all tokens")
+	dailyBudget := flag.Int64("daily-budget", 0, "billable what3words requests allowed per day, 0 disables the budget")
+	monthlyBudget := flag.Int64("monthly-budget", 0, "billable what3words requests allowed per month, 0 disables the budget")
+	usageFile := flag.String("usage-file", "", "file to persist what3words usage to; usage is kept in memory when empty")
+	flag.Parse()
+
+	if err := run(config{
+		addr:          *addr,
+		apiURL:        *apiURL,
+		apiKey:        os.Getenv(envAPIKey),
+		adminToken:    os.Getenv(envAdminToken),
+		tokensFile:    *tokensFile,
+		cacheSize:     *cacheSize,
+		cacheTTL:      *cacheTTL,
+		rate:          *rate,
+		burst:         *burst,
+		upstreamRate:  *upstreamRate,
+		upstreamBurst: *upstreamBurst,
+		dailyBudget:   *dailyBudget,
+		monthlyBudget: *monthlyBudget,
+		usageFile:     *usageFile,
+	}); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// config defines the gateway's settings
+type config struct {
+	addr          string
+	apiURL        string
+	apiKey        string
+	adminToken    string
+	tokensFile    string
+	cacheSize     int
+	cacheTTL      time.Duration
+	rate          float64
+	burst         int
+	upstreamRate  float64
+	upstreamBurst int
+	dailyBudget   int64
+	monthlyBudget int64
+	usageFile     string
+}
+
+func run(cfg config) error {
+	s, err := newServer(cfg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.adminToken == "" {
+		log.Printf("%s is not set, the admin endpoints are disabled", envAdminToken)
+	}
+
+	srv := &http.Server{
+		Addr:         cfg.addr,
+		Handler:      s.routes(),
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 45 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		log.Printf("listening on %s", cfg.addr)
+		errs <- srv.ListenAndServe()
+	}()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case err := <-errs:
+		return err
+	case <-sig:
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	return srv.Shutdown(ctx)
+}
+
+func newServer(cfg config) (*server, error) {
+	tracker, err := newTracker(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := w3w.New(cfg.apiKey, w3w.WithMiddleware(tracker.Middleware()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: set %s", err, envAPIKey)
+	}
+
+	tokens, err := newTokenStore(cfg.tokensFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &server{
+		client:     c,
+		apiURL:     cfg.apiURL,
+		adminToken: cfg.adminToken,
+		tokens:     tokens,
+		cache:      newCache(cfg.cacheSize, cfg.cacheTTL),
+		limits:     newLimiterSet(cfg.rate, cfg.burst),
+		upstream:   newLimiter(cfg.upstreamRate, cfg.upstreamBurst),
+		metrics:    newMetrics(),
+	}, nil
+}
+
+// newTracker returns the tracker counting the gateway's billable what3words
+// calls and enforcing its budgets
+func newTracker(cfg config) (*w3wquota.Tracker, error) {
+	opts := w3wquota.TrackerOptions{
+		OnError: func(err error) {
+			log.Print(err)
+		},
+	}
+
+	if cfg.usageFile != "" {
+		store, err := w3wquota.NewFileStore(cfg.usageFile)
+		if err != nil {
+			return nil, err
+		}
+		opts.Store = store
+	}
+
+	if cfg.dailyBudget > 0 {
+		opts.Budgets = append(opts.Budgets, w3wquota.Budget{Period: w3wquota.Daily, Hard: cfg.dailyBudget})
+	}
+	if cfg.monthlyBudget > 0 {
+		opts.Budgets = append(opts.Budgets, w3wquota.Budget{Period: w3wquota.Monthly, Hard: cfg.monthlyBudget})
+	}
+
+	return w3wquota.NewTracker(opts), nil
+}
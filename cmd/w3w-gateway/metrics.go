@@ -0,0 +1,80 @@
+package main
+
+import (
+	"expvar"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// metrics holds the gateway's counters, served as JSON on /metrics
+type metrics struct {
+	vars *expvar.Map
+
+	requests       *expvar.Map
+	callers        *expvar.Map
+	responses      *expvar.Map
+	upstreamErrors *expvar.Map
+	upstreamMillis *expvar.Map
+}
+
+func newMetrics() *metrics {
+	m := &metrics{
+		vars:           new(expvar.Map).Init(),
+		requests:       new(expvar.Map).Init(),
+		callers:        new(expvar.Map).Init(),
+		responses:      new(expvar.Map).Init(),
+		upstreamErrors: new(expvar.Map).Init(),
+		upstreamMillis: new(expvar.Map).Init(),
+	}
+
+	m.vars.Set("requests_by_route", m.requests)
+	m.vars.Set("requests_by_caller", m.callers)
+	m.vars.Set("responses_by_status", m.responses)
+	m.vars.Set("upstream_errors_by_code", m.upstreamErrors)
+	m.vars.Set("upstream_latency_ms_by_route", m.upstreamMillis)
+
+	return m
+}
+
+func (m *metrics) request(route string) {
+	m.requests.Add(route, 1)
+}
+
+func (m *metrics) caller(name string) {
+	m.callers.Add(name, 1)
+}
+
+func (m *metrics) response(status int) {
+	m.responses.Add(strconv.Itoa(status), 1)
+}
+
+func (m *metrics) cacheHit() {
+	m.vars.Add("cache_hits", 1)
+}
+
+func (m *metrics) cacheMiss() {
+	m.vars.Add("cache_misses", 1)
+}
+
+func (m *metrics) rateLimited() {
+	m.vars.Add("rate_limited", 1)
+}
+
+func (m *metrics) budgetExceeded() {
+	m.vars.Add("budget_exceeded", 1)
+}
+
+func (m *metrics) upstream(route string, took time.Duration, errCode string) {
+	m.vars.Add("upstream_requests", 1)
+	m.upstreamMillis.Add(route, took.Milliseconds())
+
+	if errCode != "" {
+		m.upstreamErrors.Add(errCode, 1)
+	}
+}
+
+func (m *metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(m.vars.String()))
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// limiter is a token bucket allowing rate events per second with bursts of
+// up to burst events. A zero rate disables the limit.
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newLimiter(rate float64, burst int) *limiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &limiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// Allow reports whether an event may happen now, consuming a token if so
+func (l *limiter) Allow() bool {
+	if l == nil || l.rate <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+
+	return true
+}
+
+// limiterSet holds a limiter per key, such as an internal token's ID
+type limiterSet struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    int
+	limiters map[string]*limiter
+}
+
+func newLimiterSet(rate float64, burst int) *limiterSet {
+	return &limiterSet{
+		rate:     rate,
+		burst:    burst,
+		limiters: map[string]*limiter{},
+	}
+}
+
+// Allow reports whether an event for key may happen now
+func (s *limiterSet) Allow(key string) bool {
+	if s.rate <= 0 {
+		return true
+	}
+
+	s.mu.Lock()
+	l, ok := s.limiters[key]
+	if !ok {
+		l = newLimiter(s.rate, s.burst)
+		s.limiters[key] = l
+	}
+	s.mu.Unlock()
+
+	return l.Allow()
+}
+
+// Remove forgets the limiter for key, such as a revoked token's ID
+func (s *limiterSet) Remove(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.limiters, key)
+}
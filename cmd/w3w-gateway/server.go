@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wquota"
+)
+
+const (
+	convertToCoordinatesPath = "/v3/convert-to-coordinates"
+	convertToWordsPath       = "/v3/convert-to-3wa"
+	adminTokensPath          = "/admin/tokens"
+	metricsPath              = "/metrics"
+	healthPath               = "/healthz"
+
+	routeConvertToCoordinates = "convert-to-coordinates"
+	routeConvertToWords       = "convert-to-3wa"
+
+	errCodeUnauthorized  = "InvalidKey"
+	errCodeRateLimited   = "RateLimited"
+	errCodeBadWords      = "BadWords"
+	errCodeBadCoords     = "BadCoordinates"
+	errCodeUpstream      = "UpstreamError"
+	errCodeNotFound      = "NotFound"
+	errCodeInternal      = "InternalError"
+	errCodeBadRequest    = "BadRequest"
+	errCodeQuotaExceeded = "QuotaExceeded"
+)
+
+// server exposes the conversion endpoints using the gateway's API key,
+// authenticating callers with internal tokens
+type server struct {
+	client     *w3w.Client
+	apiURL     string
+	adminToken string
+
+	tokens   *tokenStore
+	cache    *cache
+	limits   *limiterSet
+	upstream *limiter
+	metrics  *metrics
+}
+
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(convertToCoordinatesPath, s.handleConvertToCoordinates)
+	mux.HandleFunc(convertToWordsPath, s.handleConvertToWords)
+	mux.HandleFunc(adminTokensPath, s.admin(s.handleTokens))
+	mux.HandleFunc(adminTokensPath+"/", s.admin(s.handleRevokeToken))
+	mux.Handle(metricsPath, s.admin(s.metrics.ServeHTTP))
+	mux.HandleFunc(healthPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	return mux
+}
+
+func (s *server) handleConvertToCoordinates(w http.ResponseWriter, r *http.Request) {
+	if !s.authorise(w, r, routeConvertToCoordinates) {
+		return
+	}
+
+	words, err := w3w.ParseWords(r.URL.Query().Get("words"))
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, errCodeBadWords, "words must be a 3 word address, such as filled.count.soap")
+		return
+	}
+
+	s.convert(w, r, routeConvertToCoordinates, "c|"+words.String(), func(ctx context.Context) (w3w.Result, error) {
+		return s.client.GetCoordinatesContext(ctx, words, w3w.CoordinateOptions{APIURL: s.apiURL})
+	})
+}
+
+func (s *server) handleConvertToWords(w http.ResponseWriter, r *http.Request) {
+	if !s.authorise(w, r, routeConvertToWords) {
+		return
+	}
+
+	q := r.URL.Query()
+
+	coords, err := w3w.ParseCoordinates(q.Get("coordinates"))
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, errCodeBadCoords, "coordinates must be a latitude and longitude, such as 51.520847,-0.195521, 51°31'15.0\"N 0°11'43.9\"W or geo:51.520847,-0.195521")
+		return
+	}
+
+	language := strings.ToLower(q.Get("language"))
+	key := "w|" + coords.String() + "|" + language
+
+	s.convert(w, r, routeConvertToWords, key, func(ctx context.Context) (w3w.Result, error) {
+		return s.client.GetWordsContext(ctx, coords, w3w.WordOptions{
+			APIURL:   s.apiURL,
+			Language: language,
+		})
+	})
+}
+
+// convert serves the result from the cache when possible, otherwise calling
+// fn with the request's context within the upstream rate limit
+func (s *server) convert(w http.ResponseWriter, r *http.Request, route, key string, fn func(ctx context.Context) (w3w.Result, error)) {
+	if res, ok := s.cache.Get(key); ok {
+		s.metrics.cacheHit()
+		s.writeJSON(w, http.StatusOK, res)
+		return
+	}
+	s.metrics.cacheMiss()
+
+	if !s.upstream.Allow() {
+		s.metrics.rateLimited()
+		s.writeError(w, http.StatusTooManyRequests, errCodeRateLimited, "the gateway's what3words request limit has been reached")
+		return
+	}
+
+	start := time.Now()
+	res, err := fn(r.Context())
+
+	if errors.Is(err, w3wquota.ErrBudgetExceeded) {
+		s.metrics.budgetExceeded()
+		s.writeError(w, http.StatusTooManyRequests, errCodeQuotaExceeded, "the gateway's what3words budget has been used up")
+		return
+	}
+
+	var w3wErr w3w.Error
+	isW3WErr := errors.As(err, &w3wErr)
+	s.metrics.upstream(route, time.Since(start), w3wErr.Code)
+
+	if err != nil {
+		if r.Context().Err() != nil {
+			// the caller has gone, so there is no one to respond to
+			return
+		}
+
+		if !isW3WErr {
+			s.writeError(w, http.StatusBadGateway, errCodeUpstream, "error calling what3words")
+			return
+		}
+
+		s.writeError(w, upstreamStatus(w3wErr.Code), w3wErr.Code, w3wErr.Message)
+		return
+	}
+
+	s.cache.Set(key, res)
+	s.writeJSON(w, http.StatusOK, res)
+}
+
+// authorise checks the caller's internal token, taken from a bearer
+// Authorization header or the `key` query parameter so that existing
+// what3words clients can be pointed at the gateway unchanged
+func (s *server) authorise(w http.ResponseWriter, r *http.Request, route string) bool {
+	s.metrics.request(route)
+
+	if r.Method != http.MethodGet {
+		s.writeError(w, http.StatusMethodNotAllowed, errCodeBadRequest, "method not allowed")
+		return false
+	}
+
+	token := bearerToken(r)
+	if token == "" {
+		token = r.URL.Query().Get("key")
+	}
+
+	tok, ok := s.tokens.Lookup(token)
+	if !ok {
+		s.writeError(w, http.StatusUnauthorized, errCodeUnauthorized, "authentication failed; invalid gateway token")
+		return false
+	}
+	s.metrics.caller(tok.Name)
+
+	if !s.limits.Allow(tok.ID) {
+		s.metrics.rateLimited()
+		s.writeError(w, http.StatusTooManyRequests, errCodeRateLimited, "request rate limit exceeded")
+		return false
+	}
+
+	return true
+}
+
+// admin restricts h to callers presenting the admin token
+func (s *server) admin(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := bearerToken(r)
+
+		if s.adminToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) != 1 {
+			s.writeError(w, http.StatusUnauthorized, errCodeUnauthorized, "authentication failed; invalid admin token")
+			return
+		}
+
+		h(w, r)
+	}
+}
+
+// issueTokenRequest defines the request body for issuing a token
+type issueTokenRequest struct {
+	Name string `json:"name"`
+}
+
+// issueTokenResponse defines the response body for an issued token
+type issueTokenResponse struct {
+	tokenInfo
+	Token string `json:"token"`
+}
+
+func (s *server) handleTokens(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.writeJSON(w, http.StatusOK, s.tokens.List())
+	case http.MethodPost:
+		var req issueTokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+			s.writeError(w, http.StatusBadRequest, errCodeBadRequest, "a JSON body with a name is required")
+			return
+		}
+
+		token, info, err := s.tokens.Issue(req.Name)
+		if err != nil {
+			s.writeError(w, http.StatusInternalServerError, errCodeInternal, err.Error())
+			return
+		}
+
+		s.writeJSON(w, http.StatusCreated, issueTokenResponse{
+			tokenInfo: info,
+			Token:     token,
+		})
+	default:
+		s.writeError(w, http.StatusMethodNotAllowed, errCodeBadRequest, "method not allowed")
+	}
+}
+
+func (s *server) handleRevokeToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		s.writeError(w, http.StatusMethodNotAllowed, errCodeBadRequest, "method not allowed")
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, adminTokensPath+"/")
+
+	ok, err := s.tokens.Revoke(id)
+	switch {
+	case err != nil:
+		s.writeError(w, http.StatusInternalServerError, errCodeInternal, err.Error())
+	case !ok:
+		s.writeError(w, http.StatusNotFound, errCodeNotFound, "token not found")
+	default:
+		s.limits.Remove(id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// errorResponse mirrors the what3words API's error body
+type errorResponse struct {
+	Error w3w.Error `json:"error"`
+}
+
+func (s *server) writeError(w http.ResponseWriter, status int, code, message string) {
+	s.writeJSON(w, status, errorResponse{
+		Error: w3w.Error{
+			Code:    code,
+			Message: message,
+		},
+	})
+}
+
+func (s *server) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	s.metrics.response(status)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// upstreamStatus maps a what3words error code onto the gateway's response
+// status. Key errors refer to the gateway's own key so are reported as a bad
+// gateway rather than passed on as the caller's fault.
+func upstreamStatus(code string) int {
+	switch code {
+	case "InvalidKey", "MissingKey", "SuspendedKey":
+		return http.StatusBadGateway
+	case errCodeQuotaExceeded:
+		return http.StatusTooManyRequests
+	}
+
+	return http.StatusBadRequest
+}
+
+func bearerToken(r *http.Request) string {
+	const prefix = "Bearer "
+
+	h := r.Header.Get("Authorization")
+	if !strings.HasPrefix(h, prefix) {
+		return ""
+	}
+
+	return strings.TrimSpace(h[len(prefix):])
+}
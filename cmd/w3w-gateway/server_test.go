@@ -0,0 +1,401 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jonnypillar/what3words/internal/api"
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	upstreamKey = "upstream-key"
+	adminToken  = "admin-secret"
+)
+
+func TestGatewayConvert(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		path   string
+		token  string
+		header bool
+
+		expectedStatus   int
+		expectedUpstream string
+		expectedBody     string
+	}{
+		{
+			desc: "given a valid token in the key parameter, result returned",
+			path: "/v3/convert-to-coordinates?words=One.Two.Three",
+
+			expectedStatus:   http.StatusOK,
+			expectedUpstream: "/convert-to-coordinates?format=json&key=upstream-key&words=one.two.three",
+			expectedBody:     `{"country":"GB","square":{"southwest":{"lng":0,"lat":0},"northeast":{"lng":0,"lat":0}},"nearestPlace":"","coordinates":{"lng":2,"lat":1},"words":"one.two.three","language":"en","map":""}`,
+		},
+		{
+			desc:   "given a valid bearer token, result returned",
+			path:   "/v3/convert-to-3wa?coordinates=1,2&language=EN",
+			header: true,
+
+			expectedStatus:   http.StatusOK,
+			expectedUpstream: "/convert-to-3wa?coordinates=1%2C2&format=json&key=upstream-key&language=en",
+			expectedBody:     `{"country":"GB","square":{"southwest":{"lng":0,"lat":0},"northeast":{"lng":0,"lat":0}},"nearestPlace":"","coordinates":{"lng":2,"lat":1},"words":"one.two.three","language":"en","map":""}`,
+		},
+		{
+			desc:  "given an unknown token, unauthorised error returned",
+			path:  "/v3/convert-to-coordinates?words=one.two.three",
+			token: "unknown",
+
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":{"code":"InvalidKey","message":"authentication failed; invalid gateway token"}}`,
+		},
+		{
+			desc: "given malformed words, error returned without calling what3words",
+			path: "/v3/convert-to-coordinates?words=one.two",
+
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":{"code":"BadWords","message":"words must be a 3 word address, such as filled.count.soap"}}`,
+		},
+		{
+			desc: "given coordinates in degrees, minutes and seconds, result returned",
+			path: "/v3/convert-to-3wa?coordinates=" + url.QueryEscape(`1°0'0"N 2°0'0"E`),
+
+			expectedStatus:   http.StatusOK,
+			expectedUpstream: "/convert-to-3wa?coordinates=1%2C2&format=json&key=upstream-key",
+			expectedBody:     `{"country":"GB","square":{"southwest":{"lng":0,"lat":0},"northeast":{"lng":0,"lat":0}},"nearestPlace":"","coordinates":{"lng":2,"lat":1},"words":"one.two.three","language":"en","map":""}`,
+		},
+		{
+			desc: "given malformed coordinates, error returned without calling what3words",
+			path: "/v3/convert-to-3wa?coordinates=north",
+
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":{"code":"BadCoordinates","message":"coordinates must be a latitude and longitude, such as 51.520847,-0.195521, 51°31'15.0\"N 0°11'43.9\"W or geo:51.520847,-0.195521"}}`,
+		},
+		{
+			desc: "given an invalid word, error returned without calling what3words",
+			path: "/v3/convert-to-coordinates?words=one.tw0.three",
+
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":{"code":"BadWords","message":"words must be a 3 word address, such as filled.count.soap"}}`,
+		},
+		{
+			desc: "given what3words returns an error, error passed on",
+			path: "/v3/convert-to-coordinates?words=bad.bad.bad",
+
+			expectedStatus:   http.StatusBadRequest,
+			expectedUpstream: "/convert-to-coordinates?format=json&key=upstream-key&words=bad.bad.bad",
+			expectedBody:     `{"error":{"code":"BadWords","message":"Invalid or non-existent 3 word address"}}`,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			var upstreamURL string
+			upstream := upstreamServer(func(r *http.Request) {
+				upstreamURL = r.URL.String()
+			})
+			defer upstream.Close()
+
+			s := testGateway(t, upstream.URL, 0)
+			token := issueToken(t, s, "test")
+			if tt.token != "" {
+				token = tt.token
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header {
+				req.Header.Set("Authorization", "Bearer "+token)
+			} else {
+				q := req.URL.Query()
+				q.Set("key", token)
+				req.URL.RawQuery = q.Encode()
+			}
+
+			rec := httptest.NewRecorder()
+			s.routes().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedUpstream, upstreamURL)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestGatewayCachesResults(t *testing.T) {
+	var calls int32
+	upstream := upstreamServer(func(r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	})
+	defer upstream.Close()
+
+	s := testGateway(t, upstream.URL, 0)
+	gw := httptest.NewServer(s.routes())
+	defer gw.Close()
+
+	// the gateway is a drop-in API URL for the client using an internal token
+	c, err := w3w.New(issueToken(t, s, "client"))
+	assert.Nil(t, err)
+
+	for i := 0; i < 3; i++ {
+		res, err := c.GetCoordinates(w3w.Words{"one", "two", "three"}, w3w.CoordinateOptions{
+			APIURL: gw.URL + "/v3",
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "one.two.three", res.Words)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	req := httptest.NewRequest(http.MethodGet, metricsPath, nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	rec := httptest.NewRecorder()
+	s.routes().ServeHTTP(rec, req)
+
+	var m map[string]interface{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &m))
+	assert.Equal(t, float64(2), m["cache_hits"])
+	assert.Equal(t, float64(1), m["upstream_requests"])
+}
+
+func TestGatewayRateLimit(t *testing.T) {
+	upstream := upstreamServer(nil)
+	defer upstream.Close()
+
+	s := testGateway(t, upstream.URL, 2)
+	token := issueToken(t, s, "test")
+
+	var codes []int
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/v3/convert-to-coordinates?words=one.two.three", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		rec := httptest.NewRecorder()
+		s.routes().ServeHTTP(rec, req)
+		codes = append(codes, rec.Code)
+	}
+
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+}
+
+func TestGatewayCancelled(t *testing.T) {
+	started := make(chan struct{})
+	aborted := make(chan struct{})
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+		close(aborted)
+	}))
+	defer upstream.Close()
+
+	s := testGateway(t, upstream.URL, 0)
+	token := issueToken(t, s, "test")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	req := httptest.NewRequest(http.MethodGet, "/v3/convert-to-coordinates?words=one.two.three", nil).WithContext(ctx)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	rec := httptest.NewRecorder()
+	s.routes().ServeHTTP(rec, req)
+
+	select {
+	case <-aborted:
+	case <-time.After(time.Second):
+		t.Fatal("upstream request not aborted")
+	}
+
+	_, ok := s.cache.Get("c|one.two.three")
+	assert.False(t, ok)
+}
+
+func TestGatewayBudget(t *testing.T) {
+	var calls int32
+	upstream := upstreamServer(func(r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	})
+	defer upstream.Close()
+
+	cfg := config{
+		apiURL:      upstream.URL,
+		apiKey:      upstreamKey,
+		adminToken:  adminToken,
+		dailyBudget: 2,
+		usageFile:   filepath.Join(t.TempDir(), "usage.json"),
+	}
+
+	get := func(s *server, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v3/convert-to-coordinates?words=one.two.three", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		rec := httptest.NewRecorder()
+		s.routes().ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	s, err := newServer(cfg)
+	assert.Nil(t, err)
+	token := issueToken(t, s, "test")
+
+	var codes []int
+	for i := 0; i < 3; i++ {
+		codes = append(codes, get(s, token).Code)
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	// the usage survives a restart
+	s, err = newServer(cfg)
+	assert.Nil(t, err)
+
+	rec := get(s, issueToken(t, s, "test"))
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.JSONEq(t, `{"error":{"code":"QuotaExceeded","message":"the gateway's what3words budget has been used up"}}`, rec.Body.String())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestGatewayRevokeForgetsLimiter(t *testing.T) {
+	upstream := upstreamServer(nil)
+	defer upstream.Close()
+
+	s := testGateway(t, upstream.URL, 2)
+	token := issueToken(t, s, "test")
+
+	req := httptest.NewRequest(http.MethodGet, "/v3/convert-to-coordinates?words=one.two.three", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	s.routes().ServeHTTP(httptest.NewRecorder(), req)
+	assert.Len(t, s.limits.limiters, 1)
+
+	info, _ := s.tokens.Lookup(token)
+	req = httptest.NewRequest(http.MethodDelete, adminTokensPath+"/"+info.ID, nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	rec := httptest.NewRecorder()
+	s.routes().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Len(t, s.limits.limiters, 0)
+}
+
+func TestGatewayTokens(t *testing.T) {
+	s := testGateway(t, "", 0)
+	token := issueToken(t, s, "billing")
+
+	info, ok := s.tokens.Lookup(token)
+	assert.True(t, ok)
+	assert.Equal(t, "billing", info.Name)
+
+	req := httptest.NewRequest(http.MethodDelete, adminTokensPath+"/"+info.ID, nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	rec := httptest.NewRecorder()
+	s.routes().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	_, ok = s.tokens.Lookup(token)
+	assert.False(t, ok)
+
+	req = httptest.NewRequest(http.MethodPost, adminTokensPath, bytes.NewBufferString(`{"name":"x"}`))
+	req.Header.Set("Authorization", "Bearer wrong")
+	rec = httptest.NewRecorder()
+	s.routes().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func testGateway(t *testing.T, apiURL string, burst int) *server {
+	rate := 0.0
+	if burst > 0 {
+		// a negligible refill rate so the burst is the effective limit
+		rate = 0.001
+	}
+
+	s, err := newServer(config{
+		apiURL:     apiURL,
+		apiKey:     upstreamKey,
+		adminToken: adminToken,
+		cacheSize:  10,
+		cacheTTL:   time.Minute,
+		rate:       rate,
+		burst:      burst,
+	})
+	assert.Nil(t, err)
+
+	return s
+}
+
+func TestTokenStoreRevokeSaveFails(t *testing.T) {
+	store, err := newTokenStore(filepath.Join(t.TempDir(), "tokens.json"))
+	assert.Nil(t, err)
+
+	token, info, err := store.Issue("billing")
+	assert.Nil(t, err)
+
+	// the tokens file can no longer be written
+	store.path = filepath.Join(t.TempDir(), "missing", "tokens.json")
+
+	ok, err := store.Revoke(info.ID)
+	assert.NotNil(t, err)
+	assert.False(t, ok)
+
+	_, ok = store.Lookup(token)
+	assert.True(t, ok)
+}
+
+func issueToken(t *testing.T, s *server, name string) string {
+	req := httptest.NewRequest(http.MethodPost, adminTokensPath, bytes.NewBufferString(`{"name":"`+name+`"}`))
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+
+	rec := httptest.NewRecorder()
+	s.routes().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var resp issueTokenResponse
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+
+	return resp.Token
+}
+
+// upstreamServer fakes the what3words API, returning a BadWords error for
+// bad.bad.bad and a fixed result otherwise
+func upstreamServer(seen func(r *http.Request)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if seen != nil {
+			seen(r)
+		}
+
+		if r.URL.Query().Get("words") == "bad.bad.bad" {
+			var errResp api.ErrorResponse
+			errResp.Err.Code = "BadWords"
+			errResp.Err.Message = "Invalid or non-existent 3 word address"
+
+			b, _ := json.Marshal(errResp)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(b)
+			return
+		}
+
+		var resp api.Response
+		resp.Country = "GB"
+		resp.Coordinates.Lat = 1
+		resp.Coordinates.Lng = 2
+		resp.Words = "one.two.three"
+		resp.Language = "en"
+
+		b, _ := json.Marshal(resp)
+		w.Write(b)
+	}))
+}
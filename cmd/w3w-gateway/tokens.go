@@ -0,0 +1,166 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	tokenBytes  = 32
+	tokenIDSize = 12
+)
+
+// tokenInfo describes an internal token issued by the gateway
+type tokenInfo struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+}
+
+// tokenStore holds the internal tokens handed out to services in place of the
+// what3words API key. Only a hash of each token is kept, and the store is
+// written to path, when set, so tokens survive a restart.
+type tokenStore struct {
+	mu     sync.RWMutex
+	path   string
+	tokens map[string]tokenInfo
+}
+
+func newTokenStore(path string) (*tokenStore, error) {
+	s := &tokenStore{
+		path:   path,
+		tokens: map[string]tokenInfo{},
+	}
+
+	if path == "" {
+		return s, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading tokens file %w", err)
+	}
+
+	if err := json.Unmarshal(b, &s.tokens); err != nil {
+		return nil, fmt.Errorf("invalid tokens file %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// Issue creates a new token for the named service, returning the token and
+// its details. The token itself cannot be recovered later.
+func (s *tokenStore) Issue(name string) (string, tokenInfo, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", tokenInfo{}, fmt.Errorf("error generating token %w", err)
+	}
+
+	token := hex.EncodeToString(b)
+	hash := hashToken(token)
+
+	info := tokenInfo{
+		ID:      hash[:tokenIDSize],
+		Name:    name,
+		Created: time.Now().UTC(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[hash] = info
+	if err := s.save(); err != nil {
+		delete(s.tokens, hash)
+		return "", tokenInfo{}, err
+	}
+
+	return token, info, nil
+}
+
+// Revoke removes the token with the given ID, reporting whether it existed
+func (s *tokenStore) Revoke(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for hash, info := range s.tokens {
+		if info.ID == id {
+			delete(s.tokens, hash)
+			if err := s.save(); err != nil {
+				// the token is still valid on disk, so keep honouring it
+				s.tokens[hash] = info
+				return false, err
+			}
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Lookup returns the details of token, if it has been issued
+func (s *tokenStore) Lookup(token string) (tokenInfo, bool) {
+	if token == "" {
+		return tokenInfo{}, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	info, ok := s.tokens[hashToken(token)]
+
+	return info, ok
+}
+
+// List returns the issued tokens ordered by creation time
+func (s *tokenStore) List() []tokenInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]tokenInfo, 0, len(s.tokens))
+	for _, info := range s.tokens {
+		list = append(list, info)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Created.Before(list[j].Created)
+	})
+
+	return list
+}
+
+// save writes the store to disk, the caller must hold the write lock
+func (s *tokenStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	b, err := json.MarshalIndent(s.tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return fmt.Errorf("error writing tokens file %w", err)
+	}
+
+	return os.Rename(tmp, s.path)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,59 @@
+// Command w3w-grpc serves the what3words gRPC service defined in
+// proto/w3w/v1/w3w.proto.
+//
+// The what3words API key is read from the W3W_API_KEY environment variable.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wlog"
+	"github.com/jonnypillar/what3words/pkg/w3wgrpc"
+	"github.com/jonnypillar/what3words/pkg/w3wpb"
+	"google.golang.org/grpc"
+)
+
+const envAPIKey = "W3W_API_KEY"
+
+func main() {
+	addr := flag.String("addr", ":9090", "address to listen on")
+	apiURL := flag.String("api-url", "", "override the what3words API URL")
+	bulkWorkers := flag.Int("bulk-workers", 4, "concurrent conversions per BulkConvert stream")
+	flag.Parse()
+
+	c, err := w3w.New(os.Getenv(envAPIKey))
+	if err != nil {
+		log.Fatalf("%v: set %s", err, envAPIKey)
+	}
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	srv := grpc.NewServer()
+	w3wpb.RegisterWhat3WordsServiceServer(srv, w3wgrpc.NewServer(c, w3wgrpc.Options{
+		APIURL:      *apiURL,
+		BulkWorkers: *bulkWorkers,
+		Logger:      w3wlog.Std(log.New(os.Stderr, "", log.LstdFlags)),
+	}))
+
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		<-sig
+
+		srv.GracefulStop()
+	}()
+
+	log.Printf("listening on %s", lis.Addr())
+	if err := srv.Serve(lis); err != nil {
+		log.Fatal(err)
+	}
+}
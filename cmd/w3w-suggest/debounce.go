@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+)
+
+// suggestions is the outcome of an autosuggest request for input
+type suggestions struct {
+	input string
+	list  []w3w.Suggestion
+	err   error
+}
+
+// debouncer requests suggestions once the input has stopped changing for
+// delay. Each change cancels the request in flight, so suggestions for
+// stale input are never delivered.
+type debouncer struct {
+	client  w3w.Suggester
+	opts    w3w.AutoSuggestOptions
+	delay   time.Duration
+	results chan suggestions
+
+	mu     sync.Mutex
+	seq    int
+	timer  *time.Timer
+	cancel context.CancelFunc
+}
+
+func newDebouncer(client w3w.Suggester, opts w3w.AutoSuggestOptions, delay time.Duration) *debouncer {
+	return &debouncer{
+		client:  client,
+		opts:    opts,
+		delay:   delay,
+		results: make(chan suggestions),
+	}
+}
+
+// update cancels any pending or in flight request and, unless input is
+// blank, schedules a request for it. Results are sent on the results
+// channel until ctx is done.
+func (d *debouncer) update(ctx context.Context, input string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stopLocked()
+
+	d.seq++
+	seq := d.seq
+
+	if strings.TrimSpace(input) == "" {
+		return
+	}
+
+	reqCtx, cancel := context.WithCancel(ctx)
+	d.cancel = cancel
+
+	d.timer = time.AfterFunc(d.delay, func() {
+		list, err := d.client.AutoSuggestContext(reqCtx, input, d.opts)
+
+		d.mu.Lock()
+		current := seq == d.seq
+		d.mu.Unlock()
+
+		if !current || reqCtx.Err() != nil {
+			return
+		}
+
+		select {
+		case d.results <- suggestions{input: input, list: list, err: err}:
+		case <-reqCtx.Done():
+		}
+	})
+}
+
+// stop cancels any pending or in flight request
+func (d *debouncer) stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.seq++
+	d.stopLocked()
+}
+
+func (d *debouncer) stopLocked() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+
+	if d.cancel != nil {
+		d.cancel()
+		d.cancel = nil
+	}
+}
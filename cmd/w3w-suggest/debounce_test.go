@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/stretchr/testify/assert"
+)
+
+const testDelay = 20 * time.Millisecond
+
+// fakeSuggester records the inputs it is called with, blocking calls for
+// inputs in block until their context is done
+type fakeSuggester struct {
+	block map[string]bool
+
+	mu        sync.Mutex
+	inputs    []string
+	cancelled []string
+	started   chan string
+}
+
+func newFakeSuggester(block ...string) *fakeSuggester {
+	f := &fakeSuggester{
+		block:   map[string]bool{},
+		started: make(chan string, 10),
+	}
+	for _, b := range block {
+		f.block[b] = true
+	}
+
+	return f
+}
+
+func (f *fakeSuggester) AutoSuggest(input string, opts w3w.AutoSuggestOptions) ([]w3w.Suggestion, error) {
+	return f.AutoSuggestContext(context.Background(), input, opts)
+}
+
+func (f *fakeSuggester) AutoSuggestContext(ctx context.Context, input string, _ w3w.AutoSuggestOptions) ([]w3w.Suggestion, error) {
+	f.mu.Lock()
+	f.inputs = append(f.inputs, input)
+	f.mu.Unlock()
+
+	f.started <- input
+
+	if f.block[input] {
+		<-ctx.Done()
+
+		f.mu.Lock()
+		f.cancelled = append(f.cancelled, input)
+		f.mu.Unlock()
+
+		return nil, ctx.Err()
+	}
+
+	return []w3w.Suggestion{{Words: input + "p", Rank: 1}}, nil
+}
+
+func (f *fakeSuggester) calls() ([]string, []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.inputs...), append([]string(nil), f.cancelled...)
+}
+
+func TestDebouncer(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		block  []string
+		typing func(ctx context.Context, d *debouncer, f *fakeSuggester)
+
+		expectedInput     string
+		expectedCalls     []string
+		expectedCancelled []string
+	}{
+		{
+			desc: "given input typed faster than the delay, one request for the final input made",
+			typing: func(ctx context.Context, d *debouncer, f *fakeSuggester) {
+				for _, input := range []string{"f", "fi", "fil"} {
+					d.update(ctx, input)
+				}
+			},
+
+			expectedInput: "fil",
+			expectedCalls: []string{"fil"},
+		},
+		{
+			desc:  "given input changed while a request is in flight, the request cancelled",
+			block: []string{"fi"},
+			typing: func(ctx context.Context, d *debouncer, f *fakeSuggester) {
+				d.update(ctx, "fi")
+				<-f.started
+
+				d.update(ctx, "fil")
+			},
+
+			expectedInput:     "fil",
+			expectedCalls:     []string{"fi", "fil"},
+			expectedCancelled: []string{"fi"},
+		},
+		{
+			desc: "given input cleared before the delay, the pending request dropped",
+			typing: func(ctx context.Context, d *debouncer, f *fakeSuggester) {
+				d.update(ctx, "fi")
+				d.update(ctx, " ")
+				d.update(ctx, "fo")
+			},
+
+			expectedInput: "fo",
+			expectedCalls: []string{"fo"},
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			f := newFakeSuggester(tt.block...)
+			d := newDebouncer(f, w3w.AutoSuggestOptions{}, testDelay)
+			defer d.stop()
+
+			tt.typing(ctx, d, f)
+
+			select {
+			case s := <-d.results:
+				assert.Equal(t, tt.expectedInput, s.input)
+				assert.Nil(t, s.err)
+				assert.Equal(t, []w3w.Suggestion{{Words: tt.expectedInput + "p", Rank: 1}}, s.list)
+			case <-time.After(time.Second):
+				t.Fatal("no suggestions delivered")
+			}
+
+			// nothing else is delivered once the input settles
+			select {
+			case s := <-d.results:
+				t.Fatalf("unexpected suggestions for %q", s.input)
+			case <-time.After(2 * testDelay):
+			}
+
+			calls, cancelled := f.calls()
+			assert.Equal(t, tt.expectedCalls, calls)
+			assert.Equal(t, tt.expectedCancelled, cancelled)
+		})
+	}
+}
+
+func TestDebouncerStop(t *testing.T) {
+	f := newFakeSuggester("fil")
+	d := newDebouncer(f, w3w.AutoSuggestOptions{}, testDelay)
+
+	d.update(context.Background(), "fil")
+	<-f.started
+
+	d.stop()
+
+	assert.Eventually(t, func() bool {
+		_, cancelled := f.calls()
+		return len(cancelled) == 1
+	}, time.Second, time.Millisecond)
+}
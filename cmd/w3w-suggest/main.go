@@ -0,0 +1,139 @@
+// Command w3w-suggest is an interactive terminal UI for finding a 3 word
+// address from partial or misheard input.
+//
+// Usage:
+//
+//	w3w-suggest [flags]
+//
+// Suggestions are requested from the what3words autosuggest API as the
+// address is typed, once typing pauses for the debounce delay, each key
+// press cancelling the request in flight. The suggestions are listed by rank
+// with their country and nearest place. Up and down select a suggestion,
+// enter converts it to coordinates, printing the full result, and escape or
+// ctrl-c quits.
+//
+// The API key is read from the W3W_API_KEY environment variable.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"golang.org/x/term"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+	exitQuit  = 130
+
+	envAPIKey = "W3W_API_KEY"
+
+	defaultDebounce = 200 * time.Millisecond
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("w3w-suggest", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	apiURL := fs.String("api-url", "", "override the what3words API URL")
+	language := fs.String("language", "", "language of the suggested 3 word addresses")
+	nResults := fs.Int("n-results", 0, "number of suggestions shown (default 3)")
+	focus := fs.String("focus", "", "coordinates to rank suggestions near")
+	clipToCountry := fs.String("clip-to-country", "", "comma separated country codes to restrict suggestions to")
+	debounce := fs.Duration("debounce", defaultDebounce, "how long typing must pause before suggestions are requested")
+
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	opts := w3w.AutoSuggestOptions{
+		APIURL:   *apiURL,
+		Language: *language,
+		NResults: *nResults,
+	}
+	if *clipToCountry != "" {
+		opts.ClipToCountry = strings.Split(*clipToCountry, ",")
+	}
+	if *focus != "" {
+		coords, err := w3w.ParseCoordinates(*focus)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitUsage
+		}
+		opts.Focus = &coords
+	}
+
+	c, err := w3w.New(os.Getenv(envAPIKey))
+	if err != nil {
+		fmt.Fprintf(stderr, "%v: set W3W_API_KEY\n", err)
+		return exitError
+	}
+
+	u := &ui{
+		client: c,
+		opts:   w3w.CoordinateOptions{APIURL: *apiURL},
+		d:      newDebouncer(c, opts, *debounce),
+		out:    stdout,
+	}
+
+	res, err := interact(context.Background(), u, stdin)
+	switch {
+	case errors.Is(err, errQuit):
+		return exitQuit
+	case err != nil:
+		fmt.Fprintln(stderr, "Error:", err)
+		return exitError
+	}
+
+	if err := writeResult(stdout, res); err != nil {
+		fmt.Fprintln(stderr, "Error writing output:", err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+// interact runs the UI, putting the terminal into raw mode while it runs
+// when stdin is one
+func interact(ctx context.Context, u *ui, stdin io.Reader) (w3w.Result, error) {
+	if f, ok := stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		state, err := term.MakeRaw(int(f.Fd()))
+		if err != nil {
+			return w3w.Result{}, fmt.Errorf("error occurred setting up the terminal %w", err)
+		}
+		defer term.Restore(int(f.Fd()), state)
+	}
+
+	return u.run(ctx, stdin)
+}
+
+func writeResult(w io.Writer, res w3w.Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+
+	fmt.Fprintf(tw, "words:\t%s\n", res.Words)
+	fmt.Fprintf(tw, "coordinates:\t%s\n", res.Coordinates)
+	fmt.Fprintf(tw, "square:\t%s %s\n", res.Square.Southwest, res.Square.Northeast)
+	fmt.Fprintf(tw, "country:\t%s\n", res.Country)
+	fmt.Fprintf(tw, "nearest place:\t%s\n", res.NearestPlace)
+	fmt.Fprintf(tw, "language:\t%s\n", res.Language)
+	fmt.Fprintf(tw, "map:\t%s\n", res.Map)
+
+	return tw.Flush()
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var filledCountSoaps = w3w.Result{
+	Country: "GB",
+	Square: w3w.Square{
+		Southwest: w3w.LatLng{Lat: 51.5, Lng: -0.1},
+		Northeast: w3w.LatLng{Lat: 51.500027, Lng: -0.099957},
+	},
+	NearestPlace: "Westminster, London",
+	Coordinates:  w3w.LatLng{Lat: 51.500013, Lng: -0.099978},
+	Words:        "filled.count.soaps",
+	Language:     "en",
+	Map:          "https://w3w.co/filled.count.soaps",
+}
+
+// syncBuffer is a bytes.Buffer safe for the UI to write while the test reads
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		desc string
+		// keys are typed in turn, each waiting for its output to be shown
+		keys []struct{ typed, shown string }
+
+		expectedCode int
+		expectedOut  string
+	}{
+		{
+			desc: "given a suggestion picked, its result written",
+			keys: []struct{ typed, shown string }{
+				{"filled.count.so", "> 1. filled.count.soap  Bayswater, London, GB"},
+				{"\x1b[B", "> 2. filled.count.soaps  Westminster, London, GB"},
+				{"\r", ""},
+			},
+
+			expectedCode: exitOK,
+			expectedOut: "words:         filled.count.soaps\n" +
+				"coordinates:   51.500013,-0.099978\n" +
+				"square:        51.5,-0.1 51.500027,-0.099957\n" +
+				"country:       GB\n" +
+				"nearest place: Westminster, London\n" +
+				"language:      en\n" +
+				"map:           https://w3w.co/filled.count.soaps\n",
+		},
+		{
+			desc: "given input edited, suggestions for the new input shown",
+			keys: []struct{ typed, shown string }{
+				{"filled.count.soapx", "/// filled.count.soapx"},
+				{"\x7f", "> 1. filled.count.soap  Bayswater, London, GB"},
+				{"\r", ""},
+			},
+
+			expectedCode: exitOK,
+			expectedOut:  "words:         filled.count.soap\n",
+		},
+		{
+			desc: "given escape pressed, quit",
+			keys: []struct{ typed, shown string }{
+				{"filled", "/// filled"},
+				{"\x1b", ""},
+			},
+
+			expectedCode: exitQuit,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := w3wtest.NewServer(w3wtest.FilledCountSoap, filledCountSoaps)
+			defer s.Close()
+
+			os.Setenv(envAPIKey, "foobar")
+			defer os.Unsetenv(envAPIKey)
+
+			in, typing := io.Pipe()
+			defer typing.Close()
+
+			var stdout, stderr syncBuffer
+
+			code := make(chan int, 1)
+			go func() {
+				code <- run([]string{"-api-url", s.URL, "-debounce", "5ms"}, in, &stdout, &stderr)
+			}()
+
+			for _, k := range tt.keys {
+				_, err := io.WriteString(typing, k.typed)
+				assert.Nil(t, err)
+
+				if k.shown != "" {
+					assert.Eventually(t, func() bool {
+						return strings.Contains(stdout.String(), k.shown)
+					}, time.Second, time.Millisecond, "waiting for %q", k.shown)
+				}
+			}
+
+			select {
+			case c := <-code:
+				assert.Equal(t, tt.expectedCode, c)
+			case <-time.After(time.Second):
+				t.Fatal("run did not return")
+			}
+
+			// the result is written once the UI is cleared
+			assert.Contains(t, stdout.String(), "\r\x1b[J"+tt.expectedOut)
+			assert.Empty(t, stderr.String())
+
+			// every autosuggest request is for input typed so far
+			for _, r := range s.Requests() {
+				if strings.HasSuffix(r.Path, w3wtest.RouteAutoSuggest) {
+					assert.True(t, strings.HasPrefix("filled.count.soapx", r.Query().Get("input")), r.Query().Get("input"))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+)
+
+const prompt = "/// "
+
+// keys read from the terminal in raw mode
+const (
+	keyCtrlC     = 0x03
+	keyBackspace = 0x08
+	keyEnter     = '\r'
+	keyNewline   = '\n'
+	keyEscape    = 0x1b
+	keyDelete    = 0x7f
+)
+
+// controlKeys are the control keys handled, others being ignored
+var controlKeys = map[rune]bool{
+	keyCtrlC:     true,
+	keyBackspace: true,
+	keyEnter:     true,
+	keyNewline:   true,
+	keyEscape:    true,
+	keyDelete:    true,
+}
+
+var errQuit = errors.New("quit")
+
+// key is a key press, either a printable rune or one of the keys above,
+// arrows being reported as keyUp and keyDown
+type key rune
+
+const (
+	keyUp key = -1 - iota
+	keyDown
+)
+
+// service is the part of the client the UI uses
+type service interface {
+	w3w.Suggester
+	w3w.CoordinatesConverter
+}
+
+// ui shows suggestions for the address typed so far, refreshing them as the
+// input changes, and converts the one picked with enter
+type ui struct {
+	client service
+	opts   w3w.CoordinateOptions
+	d      *debouncer
+	out    io.Writer
+
+	input       []rune
+	suggestions []w3w.Suggestion
+	selected    int
+	err         error
+}
+
+// run reads key presses from in until an address is picked, returning its
+// Result, or the user quits with escape or ctrl-c, returning errQuit
+func (u *ui) run(ctx context.Context, in io.Reader) (w3w.Result, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer u.d.stop()
+
+	keys := make(chan key)
+	readErr := make(chan error, 1)
+	go func() {
+		readErr <- readKeys(ctx, in, keys)
+	}()
+
+	u.render()
+
+	for {
+		select {
+		case k := <-keys:
+			if k == keyEscape || k == keyCtrlC {
+				u.clear()
+
+				return w3w.Result{}, errQuit
+			}
+
+			words, picked := u.press(ctx, k)
+			if !picked {
+				u.render()
+				continue
+			}
+
+			res, err := u.client.GetCoordinatesContext(ctx, words, u.opts)
+			if err != nil {
+				u.err = err
+				u.render()
+				continue
+			}
+
+			u.clear()
+
+			return res, nil
+		case s := <-u.d.results:
+			if s.input != string(u.input) {
+				continue
+			}
+
+			u.suggestions, u.err = s.list, s.err
+			u.selected = 0
+			u.render()
+		case err := <-readErr:
+			if err == nil {
+				err = errQuit
+			}
+
+			return w3w.Result{}, err
+		}
+	}
+}
+
+// press handles a key, reporting the words picked when it is enter
+func (u *ui) press(ctx context.Context, k key) (w3w.Words, bool) {
+	switch k {
+	case keyUp:
+		if u.selected > 0 {
+			u.selected--
+		}
+	case keyDown:
+		if u.selected < len(u.suggestions)-1 {
+			u.selected++
+		}
+	case keyEnter, keyNewline:
+		return u.pick()
+	case keyBackspace, keyDelete:
+		if len(u.input) > 0 {
+			u.input = u.input[:len(u.input)-1]
+			u.changed(ctx)
+		}
+	default:
+		u.input = append(u.input, rune(k))
+		u.changed(ctx)
+	}
+
+	return w3w.Words{}, false
+}
+
+// pick returns the selected suggestion's words or, without suggestions,
+// the input when it is a whole address
+func (u *ui) pick() (w3w.Words, bool) {
+	text := string(u.input)
+	if len(u.suggestions) > 0 {
+		text = u.suggestions[u.selected].Words
+	}
+
+	words, err := w3w.ParseWords(text)
+	if err != nil {
+		return w3w.Words{}, false
+	}
+
+	return words, true
+}
+
+func (u *ui) changed(ctx context.Context) {
+	u.suggestions = nil
+	u.selected = 0
+	u.err = nil
+
+	u.d.update(ctx, string(u.input))
+}
+
+// render redraws the prompt and suggestions below it, leaving the cursor at
+// the end of the input
+func (u *ui) render() {
+	var b strings.Builder
+
+	b.WriteString("\r\x1b[J")
+	b.WriteString(prompt)
+	b.WriteString(string(u.input))
+
+	lines := 0
+	for i, s := range u.suggestions {
+		marker := "  "
+		if i == u.selected {
+			marker = "> "
+		}
+
+		fmt.Fprintf(&b, "\r\n%s%d. %s  %s, %s", marker, s.Rank, s.Words, s.NearestPlace, s.Country)
+		if s.DistanceToFocusKm > 0 {
+			fmt.Fprintf(&b, " (%.2fkm)", s.DistanceToFocusKm)
+		}
+		lines++
+	}
+
+	if u.err != nil {
+		fmt.Fprintf(&b, "\r\n  Error: %v", u.err)
+		lines++
+	}
+
+	if lines > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA", lines)
+	}
+	fmt.Fprintf(&b, "\r\x1b[%dC", utf8.RuneCountInString(prompt)+len(u.input))
+
+	io.WriteString(u.out, b.String())
+}
+
+// clear removes the prompt and suggestions
+func (u *ui) clear() {
+	io.WriteString(u.out, "\r\x1b[J")
+}
+
+// readKeys sends the keys read from in until it fails or ctx is done,
+// returning nil at the end of the input
+func readKeys(ctx context.Context, in io.Reader, keys chan<- key) error {
+	r := bufio.NewReader(in)
+
+	for {
+		c, _, err := r.ReadRune()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		k := key(c)
+
+		switch {
+		case c == keyEscape && r.Buffered() > 0:
+			// an escape sequence, of which only the arrows are used
+			seq := make([]byte, 2)
+			if _, err := io.ReadFull(r, seq); err != nil {
+				return err
+			}
+
+			switch string(seq) {
+			case "[A", "OA":
+				k = keyUp
+			case "[B", "OB":
+				k = keyDown
+			default:
+				continue
+			}
+		case unicode.IsControl(c):
+			if !controlKeys[c] {
+				continue
+			}
+		case !unicode.IsPrint(c):
+			continue
+		}
+
+		select {
+		case keys <- k:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	envAPIKey     = "W3W_API_KEY"
+	envConfigPath = "W3W_CONFIG"
+)
+
+// config defines the values that can be set in the config file
+type config struct {
+	Key      string `json:"key"`
+	APIURL   string `json:"apiUrl"`
+	Language string `json:"language"`
+}
+
+// loadConfig reads the config file at path, or the default location when path
+// is empty. A missing default config file is not an error.
+// The W3W_API_KEY environment variable takes precedence over the file's key.
+func loadConfig(path string) (config, error) {
+	var cfg config
+
+	explicit := path != ""
+	if !explicit {
+		path = defaultConfigPath()
+	}
+
+	if path != "" {
+		b, err := ioutil.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(b, &cfg); err != nil {
+				return config{}, fmt.Errorf("invalid config file %s: %w", path, err)
+			}
+		case os.IsNotExist(err) && !explicit:
+		default:
+			return config{}, fmt.Errorf("error reading config file %w", err)
+		}
+	}
+
+	if key := os.Getenv(envAPIKey); key != "" {
+		cfg.Key = key
+	}
+
+	return cfg, nil
+}
+
+func defaultConfigPath() string {
+	if p := os.Getenv(envConfigPath); p != "" {
+		return p
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "w3w", "config.json")
+}
@@ -0,0 +1,319 @@
+// Command w3w converts between 3 word addresses and coordinates from the shell.
+//
+// Usage:
+//
+//	w3w [flags] to-coords filled.count.soap
+//	w3w [flags] to-words 51.520847,-0.195521
+//	w3w [flags] autosuggest filled.count.so
+//	w3w [flags] grid 51.5208,-0.1956,51.5209,-0.1954
+//	w3w [flags] languages
+//
+// The API key is read from the W3W_API_KEY environment variable, falling back
+// to the `key` field of the config file.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+var errUsage = errors.New("usage")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("w3w", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { usage(fs, stderr) }
+
+	configPath := fs.String("config", "", "path to the config file (default $XDG_CONFIG_HOME/w3w/config.json)")
+	format := fs.String("format", formatText, "output format: text, json, geojson or csv")
+	language := fs.String("language", "", "language of the returned 3 word addresses (to-words and autosuggest)")
+	apiURL := fs.String("api-url", "", "override the what3words API URL")
+	nResults := fs.Int("n-results", 0, "number of suggestions returned (autosuggest only, default 3)")
+	focus := fs.String("focus", "", "coordinates to rank suggestions near (autosuggest only)")
+	clipToCountry := fs.String("clip-to-country", "", "comma separated country codes to restrict suggestions to (autosuggest only)")
+
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	// flags are also accepted after the command, e.g. `w3w to-coords -format json ...`
+	cmd := fs.Arg(0)
+	cmdArgs, err := parseCommandFlags(fs, fs.Args()[1:])
+	if err != nil {
+		return exitUsage
+	}
+
+	out, err := newFormatter(cmd, *format)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+	if *apiURL != "" {
+		cfg.APIURL = *apiURL
+	}
+
+	c, err := w3w.New(cfg.Key)
+	if err != nil {
+		fmt.Fprintf(stderr, "%v: set W3W_API_KEY or the key field in the config file\n", err)
+		return exitError
+	}
+
+	var v interface{}
+	switch cmd {
+	case "to-coords":
+		v, err = toCoords(c, cmdArgs, w3w.CoordinateOptions{
+			APIURL: cfg.APIURL,
+		})
+	case "to-words":
+		v, err = toWords(c, cmdArgs, w3w.WordOptions{
+			APIURL:   cfg.APIURL,
+			Language: firstNonEmpty(*language, cfg.Language),
+		})
+	case "autosuggest":
+		opts := w3w.AutoSuggestOptions{
+			APIURL:   cfg.APIURL,
+			Language: firstNonEmpty(*language, cfg.Language),
+			NResults: *nResults,
+		}
+		if *clipToCountry != "" {
+			opts.ClipToCountry = strings.Split(*clipToCountry, ",")
+		}
+
+		v, err = autosuggest(c, cmdArgs, *focus, opts)
+	case "grid":
+		v, err = gridSection(c, cmdArgs, w3w.GridSectionOptions{
+			APIURL: cfg.APIURL,
+		})
+	case "languages":
+		v, err = c.AvailableLanguages(w3w.LanguageOptions{
+			APIURL: cfg.APIURL,
+		})
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n", cmd)
+		fs.Usage()
+		return exitUsage
+	}
+
+	if err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprintln(stderr, err)
+			return exitUsage
+		}
+
+		fmt.Fprintln(stderr, "Error:", err)
+		return exitError
+	}
+
+	if err := out(stdout, v); err != nil {
+		fmt.Fprintln(stderr, "Error writing output:", err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+// parseCommandFlags parses the flags given after the command, returning the
+// command's arguments. Parsing stops at the first argument which is not a
+// flag or a flag's value, so negative coordinates such as -33.86,151.2 are
+// arguments rather than undefined flags.
+func parseCommandFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	n := 0
+	for n < len(args) {
+		arg := args[n]
+		if len(arg) < 2 || arg[0] != '-' || isNumeric(arg[1:]) {
+			break
+		}
+		n++
+
+		if arg == "--" {
+			break
+		}
+
+		name := strings.TrimLeft(arg, "-")
+		if strings.Contains(name, "=") {
+			continue
+		}
+
+		// the value of a non boolean flag is the next argument
+		f := fs.Lookup(name)
+		if f != nil && !isBoolFlag(f) && n < len(args) {
+			n++
+		}
+	}
+
+	if err := fs.Parse(args[:n]); err != nil {
+		return nil, err
+	}
+
+	return append(fs.Args(), args[n:]...), nil
+}
+
+func isNumeric(s string) bool {
+	return s != "" && (s[0] == '.' || (s[0] >= '0' && s[0] <= '9'))
+}
+
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+
+	return ok && b.IsBoolFlag()
+}
+
+func usage(fs *flag.FlagSet, w io.Writer) {
+	fmt.Fprintln(w, "Usage: w3w [flags] <command> <args>")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	fmt.Fprintln(w, "  to-coords <word.word.word>   convert a 3 word address into coordinates")
+	fmt.Fprintln(w, "  to-words <lat,lng>           convert coordinates into a 3 word address")
+	fmt.Fprintln(w, "  autosuggest <partial words>  suggest 3 word addresses for partial input")
+	fmt.Fprintln(w, "  grid <south,west,north,east> list the grid lines within a bounding box")
+	fmt.Fprintln(w, "  languages                    list the available languages")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	fs.PrintDefaults()
+}
+
+func toCoords(c w3w.CoordinatesConverter, args []string, opts w3w.CoordinateOptions) (w3w.Result, error) {
+	words, err := parseWords(args)
+	if err != nil {
+		return w3w.Result{}, err
+	}
+
+	return c.GetCoordinates(words, opts)
+}
+
+func toWords(c w3w.WordsConverter, args []string, opts w3w.WordOptions) (w3w.Result, error) {
+	coords, err := parseCoordinates(args)
+	if err != nil {
+		return w3w.Result{}, err
+	}
+
+	return c.GetWords(coords, opts)
+}
+
+func autosuggest(c w3w.Suggester, args []string, focus string, opts w3w.AutoSuggestOptions) ([]w3w.Suggestion, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%w: autosuggest expects partial words such as filled.count.so", errUsage)
+	}
+
+	if focus != "" {
+		coords, err := w3w.ParseCoordinates(focus)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errUsage, err)
+		}
+		opts.Focus = &coords
+	}
+
+	return c.AutoSuggest(args[0], opts)
+}
+
+func gridSection(c w3w.GridSectioner, args []string, opts w3w.GridSectionOptions) ([]w3w.Line, error) {
+	box, err := parseBoundingBox(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GridSection(box, opts)
+}
+
+// parseWords accepts either a single address in any form w3w.ParseWords
+// accepts, such as ///filled.count.soap or a w3w.co link, or the three words
+// as separate arguments
+func parseWords(args []string) (w3w.Words, error) {
+	var (
+		words w3w.Words
+		err   error
+	)
+	switch len(args) {
+	case 1:
+		words, err = w3w.ParseWords(args[0])
+	case 3:
+		words, err = w3w.ParseWords(strings.Join(args, "."))
+	default:
+		err = w3w.ErrInvalidNumberOfWords
+	}
+
+	if err != nil {
+		return w3w.Words{}, fmt.Errorf("%w: to-coords expects a 3 word address such as filled.count.soap", errUsage)
+	}
+
+	return words, nil
+}
+
+// parseCoordinates accepts either a single argument in any notation
+// w3w.ParseCoordinates supports or the latitude and longitude as separate
+// arguments
+func parseCoordinates(args []string) (w3w.LatLng, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return w3w.LatLng{}, fmt.Errorf("%w: to-words expects coordinates such as 51.520847,-0.195521", errUsage)
+	}
+
+	coords, err := w3w.ParseCoordinates(strings.Join(args, " "))
+	if err != nil {
+		return w3w.LatLng{}, fmt.Errorf("%w: %s", errUsage, err)
+	}
+
+	return coords, nil
+}
+
+// parseBoundingBox accepts a south,west,north,east box as one argument or
+// the corners as two
+func parseBoundingBox(args []string) (w3w.Square, error) {
+	errBox := fmt.Errorf("%w: grid expects a bounding box such as 51.5208,-0.1956,51.5209,-0.1954", errUsage)
+
+	parts := strings.Split(strings.Join(args, ","), ",")
+	if len(parts) != 4 {
+		return w3w.Square{}, errBox
+	}
+
+	sw, err := w3w.ParseCoordinates(parts[0] + "," + parts[1])
+	if err != nil {
+		return w3w.Square{}, errBox
+	}
+
+	ne, err := w3w.ParseCoordinates(parts[2] + "," + parts[3])
+	if err != nil {
+		return w3w.Square{}, errBox
+	}
+
+	return w3w.Square{Southwest: sw, Northeast: ne}, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
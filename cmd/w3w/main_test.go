@@ -0,0 +1,303 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jonnypillar/what3words/internal/api"
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		desc string
+		args []string
+
+		apiResponse   interface{}
+		apiStatusCode int
+
+		expectedAPIURL string
+		expectedCode   int
+		expectedOut    string
+		expectedErrOut string
+	}{
+		{
+			desc: "given to-coords with a 3 word address, text output written",
+			args: []string{"to-coords", "///one.two.three"},
+
+			apiResponse:   testResponse(),
+			apiStatusCode: http.StatusOK,
+
+			expectedAPIURL: "/convert-to-coordinates?format=json&key=foobar&words=one.two.three",
+			expectedCode:   exitOK,
+			expectedOut: "words:         one.two.three\n" +
+				"coordinates:   51.520847,-0.195521\n" +
+				"country:       GB\n" +
+				"nearest place: Bayswater, London\n" +
+				"language:      en\n" +
+				"map:           https://w3w.co/one.two.three\n",
+		},
+		{
+			desc: "given to-words with csv format flag after the command, csv output written",
+			args: []string{"to-words", "-format", "csv", "-language", "en", "51.520847,-0.195521"},
+
+			apiResponse:   testResponse(),
+			apiStatusCode: http.StatusOK,
+
+			expectedAPIURL: "/convert-to-3wa?coordinates=51.520847%2C-0.195521&format=json&key=foobar&language=en",
+			expectedCode:   exitOK,
+			expectedOut: "words,lat,lng,country,nearest_place,language,map,southwest_lat,southwest_lng,northeast_lat,northeast_lng\n" +
+				"one.two.three,51.520847,-0.195521,GB,\"Bayswater, London\",en,https://w3w.co/one.two.three,51.520833,-0.195543,51.52086,-0.195499\n",
+		},
+		{
+			desc: "given to-words with southern and western coordinates, result written",
+			args: []string{"to-words", "-format", "csv", "-33.86,-151.2"},
+
+			apiResponse:   testResponse(),
+			apiStatusCode: http.StatusOK,
+
+			expectedAPIURL: "/convert-to-3wa?coordinates=-33.86%2C-151.2&format=json&key=foobar",
+			expectedCode:   exitOK,
+			expectedOut: "words,lat,lng,country,nearest_place,language,map,southwest_lat,southwest_lng,northeast_lat,northeast_lng\n" +
+				"one.two.three,51.520847,-0.195521,GB,\"Bayswater, London\",en,https://w3w.co/one.two.three,51.520833,-0.195543,51.52086,-0.195499\n",
+		},
+		{
+			desc: "given to-words with a negative latitude as its only argument, result requested",
+			args: []string{"to-words", "-33.86,151.2"},
+
+			apiResponse:   testResponse(),
+			apiStatusCode: http.StatusOK,
+
+			expectedAPIURL: "/convert-to-3wa?coordinates=-33.86%2C151.2&format=json&key=foobar",
+			expectedCode:   exitOK,
+			expectedOut: "words:         one.two.three\n" +
+				"coordinates:   51.520847,-0.195521\n" +
+				"country:       GB\n" +
+				"nearest place: Bayswater, London\n" +
+				"language:      en\n" +
+				"map:           https://w3w.co/one.two.three\n",
+		},
+		{
+			desc: "given the W3W API returns an error, error written & non-zero exit code returned",
+			args: []string{"to-coords", "one.two.three"},
+
+			apiResponse: api.ErrorResponse{
+				Err: struct {
+					Code    string `json:"code"`
+					Message string `json:"message"`
+				}{
+					Code:    "BadWords",
+					Message: "Invalid or non-existent 3 word address",
+				},
+			},
+			apiStatusCode: http.StatusBadRequest,
+
+			expectedAPIURL: "/convert-to-coordinates?format=json&key=foobar&words=one.two.three",
+			expectedCode:   exitError,
+			expectedErrOut: "Error: BadWords: Invalid or non-existent 3 word address\n",
+		},
+		{
+			desc: "given a malformed 3 word address, usage error returned",
+			args: []string{"to-coords", "one.two"},
+
+			expectedCode:   exitUsage,
+			expectedErrOut: "usage: to-coords expects a 3 word address such as filled.count.soap\n",
+		},
+		{
+			desc: "given invalid coordinates, usage error returned",
+			args: []string{"to-words", "north,-0.195521"},
+
+			expectedCode:   exitUsage,
+			expectedErrOut: "usage: invalid coordinates \"north,-0.195521\": invalid coordinates provided\n",
+		},
+		{
+			desc: "given autosuggest with a focus, ranked suggestions written",
+			args: []string{"autosuggest", "-n-results", "2", "-focus", "51.5,-0.1", "filled.count.so"},
+
+			apiResponse: map[string]interface{}{
+				"suggestions": []w3w.Suggestion{
+					{Country: "GB", NearestPlace: "Westminster, London", Words: "filled.count.soaps", Rank: 1, Language: "en", DistanceToFocusKm: 0.004},
+					{Country: "GB", NearestPlace: "Bayswater, London", Words: "filled.count.soap", Rank: 2, Language: "en", DistanceToFocusKm: 7.352},
+				},
+			},
+			apiStatusCode: http.StatusOK,
+
+			expectedAPIURL: "/autosuggest?focus=51.5%2C-0.1&input=filled.count.so&key=foobar&n-results=2",
+			expectedCode:   exitOK,
+			expectedOut: "1 filled.count.soaps Westminster, London GB 0.00km\n" +
+				"2 filled.count.soap  Bayswater, London   GB 7.35km\n",
+		},
+		{
+			desc: "given grid with a bounding box, lines written",
+			args: []string{"grid", "51.52,-0.196,51.521,-0.195"},
+
+			apiResponse: map[string]interface{}{
+				"lines": []w3w.Line{
+					{Start: w3w.LatLng{Lat: 51.520833, Lng: -0.196}, End: w3w.LatLng{Lat: 51.520833, Lng: -0.195}},
+				},
+			},
+			apiStatusCode: http.StatusOK,
+
+			expectedAPIURL: "/grid-section?bounding-box=51.52%2C-0.196%2C51.521%2C-0.195&format=json&key=foobar",
+			expectedCode:   exitOK,
+			expectedOut:    "51.520833,-0.196 51.520833,-0.195\n",
+		},
+		{
+			desc: "given grid with a negative south bound, lines written",
+			args: []string{"grid", "-33.861,151.2", "-33.86,151.201"},
+
+			apiResponse: map[string]interface{}{
+				"lines": []w3w.Line{
+					{Start: w3w.LatLng{Lat: -33.8605, Lng: 151.2}, End: w3w.LatLng{Lat: -33.8605, Lng: 151.201}},
+				},
+			},
+			apiStatusCode: http.StatusOK,
+
+			expectedAPIURL: "/grid-section?bounding-box=-33.861%2C151.2%2C-33.86%2C151.201&format=json&key=foobar",
+			expectedCode:   exitOK,
+			expectedOut:    "-33.8605,151.2 -33.8605,151.201\n",
+		},
+		{
+			desc: "given grid with geojson format, lines written as a MultiLineString",
+			args: []string{"grid", "-format", "geojson", "51.52,-0.196", "51.521,-0.195"},
+
+			apiResponse: map[string]interface{}{
+				"lines": []w3w.Line{
+					{Start: w3w.LatLng{Lat: 51.520833, Lng: -0.196}, End: w3w.LatLng{Lat: 51.520833, Lng: -0.195}},
+				},
+			},
+			apiStatusCode: http.StatusOK,
+
+			expectedAPIURL: "/grid-section?bounding-box=51.52%2C-0.196%2C51.521%2C-0.195&format=json&key=foobar",
+			expectedCode:   exitOK,
+			expectedOut: `{
+  "type": "FeatureCollection",
+  "features": [
+    {
+      "type": "Feature",
+      "geometry": {
+        "type": "MultiLineString",
+        "coordinates": [
+          [
+            [
+              -0.196,
+              51.520833
+            ],
+            [
+              -0.195,
+              51.520833
+            ]
+          ]
+        ]
+      },
+      "properties": {}
+    }
+  ]
+}
+`,
+		},
+		{
+			desc: "given languages with json format, json output written",
+			args: []string{"-format", "json", "languages"},
+
+			apiResponse: map[string]interface{}{
+				"languages": []w3w.Language{{Code: "de", Name: "German", NativeName: "Deutsch"}},
+			},
+			apiStatusCode: http.StatusOK,
+
+			expectedAPIURL: "/available-languages?key=foobar",
+			expectedCode:   exitOK,
+			expectedOut: "[\n" +
+				"  {\n" +
+				"    \"code\": \"de\",\n" +
+				"    \"name\": \"German\",\n" +
+				"    \"nativeName\": \"Deutsch\"\n" +
+				"  }\n" +
+				"]\n",
+		},
+		{
+			desc: "given a malformed bounding box, usage error returned",
+			args: []string{"grid", "51.52,-0.196"},
+
+			expectedCode:   exitUsage,
+			expectedErrOut: "usage: grid expects a bounding box such as 51.5208,-0.1956,51.5209,-0.1954\n",
+		},
+		{
+			desc: "given languages with csv format, csv output written",
+			args: []string{"languages", "-format", "csv"},
+
+			apiResponse: map[string]interface{}{
+				"languages": []w3w.Language{{Code: "de", Name: "German", NativeName: "Deutsch"}},
+			},
+			apiStatusCode: http.StatusOK,
+
+			expectedAPIURL: "/available-languages?key=foobar",
+			expectedCode:   exitOK,
+			expectedOut:    "code,name,native_name\nde,German,Deutsch\n",
+		},
+		{
+			desc: "given a list command with a format it does not support, usage error returned",
+			args: []string{"-format", "geojson", "languages"},
+
+			expectedCode:   exitUsage,
+			expectedErrOut: "unsupported output format \"geojson\"\n",
+		},
+		{
+			desc: "given an unsupported output format, usage error returned",
+			args: []string{"-format", "xml", "to-words", "51.520847,-0.195521"},
+
+			expectedCode:   exitUsage,
+			expectedErrOut: "unsupported output format \"xml\"\n",
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.expectedAPIURL, r.URL.String())
+
+				b, _ := json.Marshal(tt.apiResponse)
+
+				w.WriteHeader(tt.apiStatusCode)
+				w.Write(b)
+			}))
+			defer s.Close()
+
+			os.Setenv(envAPIKey, "foobar")
+			os.Setenv(envConfigPath, filepath.Join(os.TempDir(), "w3w-missing-config.json"))
+			defer os.Unsetenv(envAPIKey)
+			defer os.Unsetenv(envConfigPath)
+
+			var stdout, stderr bytes.Buffer
+			code := run(append([]string{"-api-url", s.URL}, tt.args...), &stdout, &stderr)
+
+			assert.Equal(t, tt.expectedCode, code)
+			assert.Equal(t, tt.expectedOut, stdout.String())
+			assert.Equal(t, tt.expectedErrOut, stderr.String())
+		})
+	}
+}
+
+func testResponse() api.Response {
+	var resp api.Response
+
+	resp.Country = "GB"
+	resp.Square.Southwest.Lat = 51.520833
+	resp.Square.Southwest.Lng = -0.195543
+	resp.Square.Northeast.Lat = 51.52086
+	resp.Square.Northeast.Lng = -0.195499
+	resp.NearestPlace = "Bayswater, London"
+	resp.Coordinates.Lat = 51.520847
+	resp.Coordinates.Lng = -0.195521
+	resp.Words = "one.two.three"
+	resp.Language = "en"
+	resp.Map = "https://w3w.co/one.two.three"
+
+	return resp
+}
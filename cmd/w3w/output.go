@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+)
+
+const (
+	formatText    = "text"
+	formatJSON    = "json"
+	formatGeoJSON = "geojson"
+	formatCSV     = "csv"
+)
+
+var csvHeader = []string{
+	"words",
+	"lat",
+	"lng",
+	"country",
+	"nearest_place",
+	"language",
+	"map",
+	"southwest_lat",
+	"southwest_lng",
+	"northeast_lat",
+	"northeast_lng",
+}
+
+// formatter writes a command's output to an output stream
+type formatter func(w io.Writer, v interface{}) error
+
+// newFormatter returns the formatter for cmd's output. Of the commands
+// returning lists only grid supports the geojson format.
+func newFormatter(cmd, format string) (formatter, error) {
+	var write func(w io.Writer, res w3w.Result) error
+
+	switch cmd {
+	case "autosuggest", "grid", "languages":
+		switch {
+		case format == formatText || format == "":
+			return writeList, nil
+		case format == formatJSON:
+			return writeJSON, nil
+		case format == formatCSV:
+			return writeListCSV, nil
+		case format == formatGeoJSON && cmd == "grid":
+			return writeLinesGeoJSON, nil
+		}
+	default:
+		switch format {
+		case formatText, "":
+			write = writeText
+		case formatJSON:
+			return writeJSON, nil
+		case formatGeoJSON:
+			write = writeGeoJSON
+		case formatCSV:
+			write = writeCSV
+		}
+	}
+
+	if write == nil {
+		return nil, fmt.Errorf("unsupported output format %q", format)
+	}
+
+	return func(w io.Writer, v interface{}) error {
+		return write(w, v.(w3w.Result))
+	}, nil
+}
+
+func writeText(w io.Writer, res w3w.Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+
+	fmt.Fprintf(tw, "words:\t%s\n", res.Words)
+	fmt.Fprintf(tw, "coordinates:\t%s,%s\n", formatFloat(res.Coordinates.Lat), formatFloat(res.Coordinates.Lng))
+	fmt.Fprintf(tw, "country:\t%s\n", res.Country)
+	fmt.Fprintf(tw, "nearest place:\t%s\n", res.NearestPlace)
+	fmt.Fprintf(tw, "language:\t%s\n", res.Language)
+	fmt.Fprintf(tw, "map:\t%s\n", res.Map)
+
+	return tw.Flush()
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(v)
+}
+
+// writeList writes a line of text for each suggestion, grid line or language
+func writeList(w io.Writer, v interface{}) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+
+	switch v := v.(type) {
+	case []w3w.Suggestion:
+		for _, s := range v {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s", s.Rank, s.Words, s.NearestPlace, s.Country)
+			if s.DistanceToFocusKm > 0 {
+				fmt.Fprintf(tw, "\t%.2fkm", s.DistanceToFocusKm)
+			}
+			fmt.Fprintln(tw)
+		}
+	case []w3w.Line:
+		for _, l := range v {
+			fmt.Fprintf(tw, "%s\t%s\n", l.Start, l.End)
+		}
+	case []w3w.Language:
+		for _, l := range v {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", l.Code, l.Name, l.NativeName)
+		}
+	}
+
+	return tw.Flush()
+}
+
+// geoJSONFeatureCollection mirrors the shape of the W3W API's geojson format
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string            `json:"type"`
+	BBox       [4]float64        `json:"bbox"`
+	Geometry   geoJSONGeometry   `json:"geometry"`
+	Properties map[string]string `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+func writeGeoJSON(w io.Writer, res w3w.Result) error {
+	fc := geoJSONFeatureCollection{
+		Type: "FeatureCollection",
+		Features: []geoJSONFeature{
+			{
+				Type: "Feature",
+				BBox: [4]float64{
+					res.Square.Southwest.Lng,
+					res.Square.Southwest.Lat,
+					res.Square.Northeast.Lng,
+					res.Square.Northeast.Lat,
+				},
+				Geometry: geoJSONGeometry{
+					Type:        "Point",
+					Coordinates: [2]float64{res.Coordinates.Lng, res.Coordinates.Lat},
+				},
+				Properties: map[string]string{
+					"country":      res.Country,
+					"nearestPlace": res.NearestPlace,
+					"words":        res.Words,
+					"language":     res.Language,
+					"map":          res.Map,
+				},
+			},
+		},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(fc)
+}
+
+// geoJSONLines is a feature collection holding grid lines as a single
+// MultiLineString
+type geoJSONLines struct {
+	Type     string               `json:"type"`
+	Features []geoJSONLineFeature `json:"features"`
+}
+
+type geoJSONLineFeature struct {
+	Type       string              `json:"type"`
+	Geometry   geoJSONMultiLine    `json:"geometry"`
+	Properties map[string]struct{} `json:"properties"`
+}
+
+type geoJSONMultiLine struct {
+	Type        string         `json:"type"`
+	Coordinates [][][2]float64 `json:"coordinates"`
+}
+
+func writeLinesGeoJSON(w io.Writer, v interface{}) error {
+	lines := v.([]w3w.Line)
+
+	coords := make([][][2]float64, 0, len(lines))
+	for _, l := range lines {
+		coords = append(coords, [][2]float64{
+			{l.Start.Lng, l.Start.Lat},
+			{l.End.Lng, l.End.Lat},
+		})
+	}
+
+	fc := geoJSONLines{
+		Type: "FeatureCollection",
+		Features: []geoJSONLineFeature{
+			{
+				Type: "Feature",
+				Geometry: geoJSONMultiLine{
+					Type:        "MultiLineString",
+					Coordinates: coords,
+				},
+				Properties: map[string]struct{}{},
+			},
+		},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(fc)
+}
+
+func writeCSV(w io.Writer, res w3w.Result) error {
+	cw := csv.NewWriter(w)
+
+	_ = cw.Write(csvHeader)
+	_ = cw.Write([]string{
+		res.Words,
+		formatFloat(res.Coordinates.Lat),
+		formatFloat(res.Coordinates.Lng),
+		res.Country,
+		res.NearestPlace,
+		res.Language,
+		res.Map,
+		formatFloat(res.Square.Southwest.Lat),
+		formatFloat(res.Square.Southwest.Lng),
+		formatFloat(res.Square.Northeast.Lat),
+		formatFloat(res.Square.Northeast.Lng),
+	})
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// writeListCSV writes a csv row for each suggestion, grid line or language
+func writeListCSV(w io.Writer, v interface{}) error {
+	cw := csv.NewWriter(w)
+
+	switch v := v.(type) {
+	case []w3w.Suggestion:
+		_ = cw.Write([]string{"rank", "words", "country", "nearest_place", "language", "distance_to_focus_km"})
+		for _, s := range v {
+			_ = cw.Write([]string{
+				strconv.Itoa(s.Rank),
+				s.Words,
+				s.Country,
+				s.NearestPlace,
+				s.Language,
+				formatFloat(s.DistanceToFocusKm),
+			})
+		}
+	case []w3w.Line:
+		_ = cw.Write([]string{"start_lat", "start_lng", "end_lat", "end_lng"})
+		for _, l := range v {
+			_ = cw.Write([]string{
+				formatFloat(l.Start.Lat),
+				formatFloat(l.Start.Lng),
+				formatFloat(l.End.Lat),
+				formatFloat(l.End.Lng),
+			})
+		}
+	case []w3w.Language:
+		_ = cw.Write([]string{"code", "name", "native_name"})
+		for _, l := range v {
+			_ = cw.Write([]string{l.Code, l.Name, l.NativeName})
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
@@ -13,7 +13,7 @@ func main() {
 
 	c, _ := w3w.New(apiKey)
 
-	res, err := c.GetWords(w3w.Coordinates{
+	res, err := c.GetWords(w3w.LatLng{
 		Lat: 51.432393,
 		Lng: -0.348023,
 	}, w3w.WordOptions{})
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+// Route returns the W3W route requested by u, such as convert-to-3wa
+func Route(u *url.URL) string {
+	return u.Path[strings.LastIndex(u.Path, "/")+1:]
+}
+
+// Redact returns u as a string with the value of its API key replaced
+func Redact(u *url.URL) string {
+	q := u.Query()
+	if _, ok := q[paramKey]; !ok {
+		return u.String()
+	}
+
+	q.Set(paramKey, redacted)
+
+	r := *u
+	r.RawQuery = q.Encode()
+
+	return r.String()
+}
+
+// RedactError replaces the value of the API key in the URL of the
+// *url.Error in err's chain, if it has one, returning err
+func RedactError(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+
+	u, parseErr := url.Parse(urlErr.URL)
+	if parseErr != nil {
+		// the key can't be found, so nothing of the URL is kept
+		urlErr.URL = redacted
+		return err
+	}
+
+	urlErr.URL = Redact(u)
+
+	return err
+}
+
+// ErrorCode returns the W3W error code in the body of a failed response, or
+// an empty string if it has none. The body is buffered so it can be read
+// again. If reading it fails, the body is left to read from the bytes
+// already read followed by the rest, so the caller sees the same failure,
+// and must still be closed.
+func ErrorCode(resp *http.Response) (string, error) {
+	if resp.StatusCode == http.StatusOK {
+		return "", nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		resp.Body = readCloser{
+			Reader: io.MultiReader(bytes.NewReader(body), resp.Body),
+			Closer: resp.Body,
+		}
+		return "", err
+	}
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	var errResp ErrorResponse
+	if json.Unmarshal(body, &errResp) != nil {
+		return "", nil
+	}
+
+	return errResp.Err.Code, nil
+}
+
+// readCloser reads from Reader and closes Closer
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
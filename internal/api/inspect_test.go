@@ -0,0 +1,111 @@
+package api_test
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/jonnypillar/what3words/internal/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	testCases := []struct {
+		desc string
+		url  string
+
+		expectedURL string
+	}{
+		{
+			desc: "given a URL with a key, key redacted",
+			url:  "https://example.com/convert-to-3wa?coordinates=51.5%2C-0.19&key=FooBar",
+
+			expectedURL: "https://example.com/convert-to-3wa?coordinates=51.5%2C-0.19&key=REDACTED",
+		},
+		{
+			desc: "given a URL without a key, URL returned",
+			url:  "https://example.com/convert-to-3wa?coordinates=51.5%2C-0.19",
+
+			expectedURL: "https://example.com/convert-to-3wa?coordinates=51.5%2C-0.19",
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tt.expectedURL, api.Redact(u))
+		})
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		status int
+		body   string
+
+		expectedCode string
+	}{
+		{
+			desc:   "given a successful response, no code returned",
+			status: http.StatusOK,
+			body:   `{"words":"filled.count.soap"}`,
+		},
+		{
+			desc:   "given an error response, code returned",
+			status: http.StatusBadRequest,
+			body:   `{"error":{"code":"BadWords","message":"Invalid or non-existent 3 word address"}}`,
+
+			expectedCode: "BadWords",
+		},
+		{
+			desc:   "given an error response without JSON, no code returned",
+			status: http.StatusBadGateway,
+			body:   "Bad Gateway",
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.status,
+				Body:       ioutil.NopCloser(strings.NewReader(tt.body)),
+			}
+
+			code, err := api.ErrorCode(resp)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectedCode, code)
+
+			body, err := ioutil.ReadAll(resp.Body)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.body, string(body))
+		})
+	}
+}
+
+func TestErrorCodeReadError(t *testing.T) {
+	readErr := errors.New("connection reset")
+
+	resp := &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Body: ioutil.NopCloser(io.MultiReader(
+			strings.NewReader(`{"error":`),
+			iotest.ErrReader(readErr),
+		)),
+	}
+
+	_, err := api.ErrorCode(resp)
+	assert.Equal(t, readErr, err)
+
+	// the body still holds the bytes read before the failure, then fails
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, readErr, err)
+	assert.Equal(t, `{"error":`, string(body))
+}
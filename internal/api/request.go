@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -12,14 +13,56 @@ const (
 	requestTimeout = 30 * time.Second
 )
 
-// Get ...
-func Get(url string) (*Response, error) {
-	http.DefaultClient.Timeout = requestTimeout
+var httpClient = &http.Client{
+	Timeout: requestTimeout,
+}
+
+// DefaultClient returns a copy of the client used when Get is not given one
+func DefaultClient() *http.Client {
+	c := *httpClient
+
+	return &c
+}
 
-	resp, err := http.Get(url)
+// RawResponse is an undecoded API response
+type RawResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Get performs a GET request against url using client, or a default client
+// when nil, cancelling the request when ctx is done
+func Get(ctx context.Context, client *http.Client, url string) (*Response, error) {
+	raw, err := Fetch(ctx, client, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode(raw)
+}
+
+// Fetch performs a GET request against url with the extra header using
+// client, or a default client when nil, returning the undecoded response
+func Fetch(ctx context.Context, client *http.Client, url string, header http.Header) (*RawResponse, error) {
+	if client == nil {
+		client = httpClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error occurred performing get request %w", err)
 	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// the error quotes the URL, which holds the API key
+		return nil, fmt.Errorf("error occurred performing get request %w", RedactError(err))
+	}
 	defer resp.Body.Close()
 
 	body, err := ioutil.ReadAll(resp.Body)
@@ -27,22 +70,44 @@ func Get(url string) (*Response, error) {
 		return nil, fmt.Errorf("error occurred reading response body %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
+	return &RawResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}, nil
+}
+
+// Decode decodes a raw response, returning an ErrorResponse for error
+// statuses
+func Decode(raw *RawResponse) (*Response, error) {
+	var wResp Response
+
+	err := DecodeInto(raw, &wResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wResp, nil
+}
+
+// DecodeInto decodes a raw response into v, returning an ErrorResponse for
+// error statuses
+func DecodeInto(raw *RawResponse, v interface{}) error {
+	if raw.StatusCode != http.StatusOK {
 		var errResp ErrorResponse
 
-		err = json.Unmarshal(body, &errResp)
+		err := json.Unmarshal(raw.Body, &errResp)
 		if err != nil {
-			return nil, fmt.Errorf("invalid error JSON returned from API %w", err)
+			return fmt.Errorf("invalid error JSON returned from API %w", err)
 		}
 
-		return nil, errResp
+		return errResp
 	}
 
-	var wResp Response
-	err = json.Unmarshal(body, &wResp)
+	err := json.Unmarshal(raw.Body, v)
 	if err != nil {
-		return nil, fmt.Errorf("invalid JSON returned from API %w", err)
+		return fmt.Errorf("invalid JSON returned from API %w", err)
 	}
 
-	return &wResp, nil
+	return nil
 }
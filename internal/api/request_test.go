@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -109,7 +110,7 @@ func TestGet(t *testing.T) {
 			} else {
 				url = s.URL
 			}
-			resp, err := api.Get(url)
+			resp, err := api.Get(context.Background(), nil, url)
 
 			if tt.expectedErr != nil {
 				assert.EqualError(t, err, tt.expectedErr.Error())
@@ -121,6 +122,17 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestGetRedactsKey(t *testing.T) {
+	s := testServer(func(w http.ResponseWriter, r *http.Request) {})
+	s.Close()
+
+	_, err := api.Get(context.Background(), nil, s.URL+"/convert-to-3wa?coordinates=1%2C2&key=secret")
+
+	assert.NotNil(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+	assert.Contains(t, err.Error(), "key=REDACTED")
+}
+
 func testServer(h func(w http.ResponseWriter, r *http.Request)) *httptest.Server {
 	server := httptest.NewServer(
 		http.HandlerFunc(h),
@@ -7,6 +7,8 @@ import (
 
 const (
 	w3wAPIURL = "https://api.what3words.com/v3"
+
+	paramKey = "key"
 )
 
 // URL ...
@@ -39,7 +41,7 @@ func NewURL(apiKey, baseURL, route string) (*URL, error) {
 		return nil, fmt.Errorf("invalid api key")
 	}
 
-	params.Add("key", apiKey)
+	params.Add(paramKey, apiKey)
 
 	return &URL{
 		url:    u,
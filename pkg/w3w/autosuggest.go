@@ -0,0 +1,185 @@
+package w3w
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jonnypillar/what3words/internal/api"
+)
+
+const (
+	autosuggestRoute = "autosuggest"
+
+	paramInput             = "input"
+	paramNResults          = "n-results"
+	paramFocus             = "focus"
+	paramNFocusResults     = "n-focus-results"
+	paramClipToCountry     = "clip-to-country"
+	paramClipToBoundingBox = "clip-to-bounding-box"
+	paramClipToCircle      = "clip-to-circle"
+
+	// maxSuggestions is the most suggestions the API returns
+	maxSuggestions = 100
+)
+
+// Suggestion is a 3 word address suggested for partial input
+type Suggestion struct {
+	Country      string `json:"country"`
+	NearestPlace string `json:"nearestPlace"`
+	Words        string `json:"words"`
+	// Rank orders the suggestions, 1 being the most likely
+	Rank     int    `json:"rank"`
+	Language string `json:"language"`
+	// DistanceToFocusKm is only set when a focus is given
+	DistanceToFocusKm float64 `json:"distanceToFocusKm,omitempty"`
+}
+
+// Circle is an area of RadiusKm around Center
+type Circle struct {
+	Center   LatLng
+	RadiusKm float64
+}
+
+// AutoSuggestOptions ...
+type AutoSuggestOptions struct {
+	APIURL   string
+	Language string
+	// NResults is the number of suggestions returned, the API's default of
+	// 3 when 0 and at most 100
+	NResults int
+	// Focus ranks suggestions near the point higher
+	Focus *LatLng
+	// NFocusResults is how many of the suggestions are ranked by Focus
+	NFocusResults int
+	// ClipToCountry restricts suggestions to ISO 3166-1 alpha-2 country codes
+	ClipToCountry []string
+	// ClipToBoundingBox restricts suggestions to the square
+	ClipToBoundingBox *Square
+	// ClipToCircle restricts suggestions to the circle
+	ClipToCircle *Circle
+	// Key overrides the client's API key for the call
+	Key string
+}
+
+// autosuggestResponse defines the autosuggest response body
+type autosuggestResponse struct {
+	Suggestions []Suggestion `json:"suggestions"`
+}
+
+// AutoSuggest returns 3 word addresses matching partial or mistyped input,
+// such as "filled.count.so", most likely first
+func (c Client) AutoSuggest(input string, opts AutoSuggestOptions) ([]Suggestion, error) {
+	return c.AutoSuggestContext(context.Background(), input, opts)
+}
+
+// AutoSuggestContext is AutoSuggest with a context, which cancels the request when done
+func (c Client) AutoSuggestContext(ctx context.Context, input string, opts AutoSuggestOptions) ([]Suggestion, error) {
+	err := opts.validate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := c.selectKey(ctx, opts.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := api.NewURL(key, opts.APIURL, autosuggestRoute)
+	if err != nil {
+		return nil, err
+	}
+
+	url.AddParam(paramInput, strings.TrimSpace(input))
+	opts.addParams(url)
+
+	var resp autosuggestResponse
+
+	err = c.call(ctx, key, url.URL(), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Suggestions, nil
+}
+
+func (o AutoSuggestOptions) validate(input string) error {
+	if strings.TrimSpace(input) == "" {
+		return ErrEmptyInput
+	}
+
+	if o.NResults < 0 || o.NResults > maxSuggestions {
+		return fmt.Errorf("%w: n-results must be between 1 and %d", ErrInvalidOption, maxSuggestions)
+	}
+
+	if o.NFocusResults < 0 || o.NFocusResults > o.nResults() {
+		return fmt.Errorf("%w: n-focus-results must not exceed n-results", ErrInvalidOption)
+	}
+
+	if o.Focus != nil {
+		err := o.Focus.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.ClipToBoundingBox != nil {
+		err := o.ClipToBoundingBox.validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.ClipToCircle != nil {
+		err := o.ClipToCircle.Center.Validate()
+		if err != nil {
+			return err
+		}
+
+		if !(o.ClipToCircle.RadiusKm > 0) {
+			return fmt.Errorf("%w: clip-to-circle radius must be positive", ErrInvalidOption)
+		}
+	}
+
+	return nil
+}
+
+// nResults returns the number of suggestions the API will return
+func (o AutoSuggestOptions) nResults() int {
+	if o.NResults == 0 {
+		return 3
+	}
+
+	return o.NResults
+}
+
+func (o AutoSuggestOptions) addParams(url *api.URL) {
+	if o.Language != "" {
+		url.AddParam(paramLanguage, o.Language)
+	}
+
+	if o.NResults > 0 {
+		url.AddParam(paramNResults, strconv.Itoa(o.NResults))
+	}
+
+	if o.Focus != nil {
+		url.AddParam(paramFocus, o.Focus.String())
+
+		if o.NFocusResults > 0 {
+			url.AddParam(paramNFocusResults, strconv.Itoa(o.NFocusResults))
+		}
+	}
+
+	if len(o.ClipToCountry) > 0 {
+		url.AddParam(paramClipToCountry, strings.ToUpper(strings.Join(o.ClipToCountry, ",")))
+	}
+
+	if o.ClipToBoundingBox != nil {
+		url.AddParam(paramClipToBoundingBox, o.ClipToBoundingBox.param())
+	}
+
+	if o.ClipToCircle != nil {
+		url.AddParam(paramClipToCircle, o.ClipToCircle.Center.String()+","+strconv.FormatFloat(o.ClipToCircle.RadiusKm, 'f', -1, 64))
+	}
+}
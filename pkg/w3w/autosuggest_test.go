@@ -0,0 +1,190 @@
+package w3w_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var filledCountSoaps = w3w.Result{
+	Country: "GB",
+	Square: w3w.Square{
+		Southwest: w3w.LatLng{Lat: 51.5, Lng: -0.1},
+		Northeast: w3w.LatLng{Lat: 51.500027, Lng: -0.099957},
+	},
+	NearestPlace: "Westminster, London",
+	Coordinates:  w3w.LatLng{Lat: 51.500013, Lng: -0.099978},
+	Words:        "filled.count.soaps",
+	Language:     "en",
+	Map:          "https://w3w.co/filled.count.soaps",
+}
+
+func TestAutoSuggest(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		input    string
+		opts     w3w.AutoSuggestOptions
+		apiError string
+
+		expectedWords    []string
+		expectedParams   map[string]string
+		expectedRequests int
+		expectedErr      error
+	}{
+		{
+			desc:  "given partial words, matching fixtures returned in rank order",
+			input: "filled.count.so",
+
+			expectedWords:    []string{"filled.count.soap", "filled.count.soaps"},
+			expectedParams:   map[string]string{"input": "filled.count.so"},
+			expectedRequests: 1,
+		},
+		{
+			desc:  "given a number of results, no more suggestions returned",
+			input: "filled.count.so",
+			opts:  w3w.AutoSuggestOptions{NResults: 1},
+
+			expectedWords:    []string{"filled.count.soap"},
+			expectedParams:   map[string]string{"n-results": "1"},
+			expectedRequests: 1,
+		},
+		{
+			desc:  "given a focus, suggestions ordered by distance returned",
+			input: "filled.count.so",
+			opts:  w3w.AutoSuggestOptions{Focus: &w3w.LatLng{Lat: 51.5, Lng: -0.1}},
+
+			expectedWords:    []string{"filled.count.soaps", "filled.count.soap"},
+			expectedParams:   map[string]string{"focus": "51.5,-0.1"},
+			expectedRequests: 1,
+		},
+		{
+			desc:  "given a country clip, only suggestions in the countries returned",
+			input: "filled.count.so",
+			opts:  w3w.AutoSuggestOptions{ClipToCountry: []string{"fr", "de"}},
+
+			expectedWords:    []string{},
+			expectedParams:   map[string]string{"clip-to-country": "FR,DE"},
+			expectedRequests: 1,
+		},
+		{
+			desc:  "given a bounding box clip, only suggestions in the box returned",
+			input: "filled.count.so",
+			opts: w3w.AutoSuggestOptions{ClipToBoundingBox: &w3w.Square{
+				Southwest: w3w.LatLng{Lat: 51.51, Lng: -0.2},
+				Northeast: w3w.LatLng{Lat: 51.53, Lng: -0.19},
+			}},
+
+			expectedWords:    []string{"filled.count.soap"},
+			expectedParams:   map[string]string{"clip-to-bounding-box": "51.51,-0.2,51.53,-0.19"},
+			expectedRequests: 1,
+		},
+		{
+			desc:  "given a circle clip, only suggestions in the circle returned",
+			input: "filled.count.so",
+			opts: w3w.AutoSuggestOptions{ClipToCircle: &w3w.Circle{
+				Center:   w3w.LatLng{Lat: 51.5, Lng: -0.1},
+				RadiusKm: 1.5,
+			}},
+
+			expectedWords:    []string{"filled.count.soaps"},
+			expectedParams:   map[string]string{"clip-to-circle": "51.5,-0.1,1.5"},
+			expectedRequests: 1,
+		},
+		{
+			desc:  "given empty input, ErrEmptyInput returned",
+			input: " ",
+
+			expectedErr: w3w.ErrEmptyInput,
+		},
+		{
+			desc:  "given too many results, ErrInvalidOption returned",
+			input: "filled.count.so",
+			opts:  w3w.AutoSuggestOptions{NResults: 101},
+
+			expectedErr: w3w.ErrInvalidOption,
+		},
+		{
+			desc:  "given an invalid focus, ErrLatitudeOutOfRange returned",
+			input: "filled.count.so",
+			opts:  w3w.AutoSuggestOptions{Focus: &w3w.LatLng{Lat: 91}},
+
+			expectedErr: w3w.ErrLatitudeOutOfRange,
+		},
+		{
+			desc:     "given the API returns an error, error returned",
+			input:    "filled.count.so",
+			apiError: w3wtest.QuotaExceeded,
+
+			expectedRequests: 1,
+			expectedErr:      w3w.Error{Code: w3wtest.QuotaExceeded, Message: "Quota Exceeded. Please upgrade your usage plan, or contact support@what3words.com"},
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := w3wtest.NewServer(w3wtest.FilledCountSoap, filledCountSoaps)
+			defer s.Close()
+
+			if tt.apiError != "" {
+				s.InjectError(w3wtest.RouteAutoSuggest, tt.apiError, 1)
+			}
+
+			c, err := w3w.New("foobar")
+			assert.Nil(t, err)
+
+			opts := tt.opts
+			opts.APIURL = s.URL
+
+			suggestions, err := c.AutoSuggest(tt.input, opts)
+
+			reqs := s.Requests()
+			assert.Len(t, reqs, tt.expectedRequests)
+
+			if tt.expectedErr != nil {
+				assert.True(t, errors.Is(err, tt.expectedErr), "expected %v, got %v", tt.expectedErr, err)
+				return
+			}
+			assert.Nil(t, err)
+
+			words := []string{}
+			for i, sug := range suggestions {
+				assert.Equal(t, i+1, sug.Rank)
+				words = append(words, sug.Words)
+			}
+			assert.Equal(t, tt.expectedWords, words)
+
+			for k, v := range tt.expectedParams {
+				assert.Equal(t, v, reqs[0].Query().Get(k), k)
+			}
+		})
+	}
+}
+
+func TestAutoSuggestDistanceToFocus(t *testing.T) {
+	s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+	defer s.Close()
+
+	c, err := w3w.New("foobar")
+	assert.Nil(t, err)
+
+	focus := w3w.LatLng{Lat: 51.5, Lng: -0.1}
+
+	suggestions, err := c.AutoSuggest("filled.count.soap", w3w.AutoSuggestOptions{
+		APIURL: s.URL,
+		Focus:  &focus,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, suggestions, 1)
+
+	assert.Equal(t, w3w.Suggestion{
+		Country:           "GB",
+		NearestPlace:      "Bayswater, London",
+		Words:             "filled.count.soap",
+		Rank:              1,
+		Language:          "en",
+		DistanceToFocusKm: focus.DistanceTo(w3wtest.FilledCountSoap.Coordinates) / 1000,
+	}, suggestions[0])
+}
@@ -0,0 +1,61 @@
+package w3w
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultWorkers is the number of requests made concurrently by calls which
+// resolve several addresses
+const defaultWorkers = 4
+
+// forEach calls fn for 0 to n-1 on up to workers goroutines. The first
+// error fn returns cancels the context passed to the remaining calls and is
+// returned, as is the error of ctx if it ends before every call is made.
+func forEach(ctx context.Context, n, workers int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	indexes := make(chan int)
+	go func() {
+		defer close(indexes)
+
+		for i := 0; i < n; i++ {
+			select {
+			case indexes <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				if err := fn(ctx, i); err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
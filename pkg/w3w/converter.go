@@ -0,0 +1,51 @@
+package w3w
+
+import "context"
+
+// CoordinatesConverter converts 3 word addresses into coordinates
+type CoordinatesConverter interface {
+	GetCoordinates(req Words, opts CoordinateOptions) (Result, error)
+	GetCoordinatesContext(ctx context.Context, req Words, opts CoordinateOptions) (Result, error)
+}
+
+// WordsConverter converts coordinates into 3 word addresses
+type WordsConverter interface {
+	GetWords(req LatLng, opts WordOptions) (Result, error)
+	GetWordsContext(ctx context.Context, req LatLng, opts WordOptions) (Result, error)
+}
+
+// Converter converts between 3 word addresses and coordinates. Depend on
+// the narrower interfaces where only one conversion is needed.
+type Converter interface {
+	CoordinatesConverter
+	WordsConverter
+}
+
+// Suggester suggests 3 word addresses for partial input
+type Suggester interface {
+	AutoSuggest(input string, opts AutoSuggestOptions) ([]Suggestion, error)
+	AutoSuggestContext(ctx context.Context, input string, opts AutoSuggestOptions) ([]Suggestion, error)
+}
+
+// GridSectioner returns sections of the what3words grid
+type GridSectioner interface {
+	GridSection(box Square, opts GridSectionOptions) ([]Line, error)
+	GridSectionContext(ctx context.Context, box Square, opts GridSectionOptions) ([]Line, error)
+}
+
+// LanguageLister lists the languages 3 word addresses are available in
+type LanguageLister interface {
+	AvailableLanguages(opts LanguageOptions) ([]Language, error)
+	AvailableLanguagesContext(ctx context.Context, opts LanguageOptions) ([]Language, error)
+}
+
+// Service defines everything a Client can do, so code using the client can
+// depend on it and be tested with a mock such as w3wmock.Converter
+type Service interface {
+	Converter
+	Suggester
+	GridSectioner
+	LanguageLister
+}
+
+var _ Service = Client{}
@@ -0,0 +1,248 @@
+package w3w
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const geoScheme = "geo:"
+
+// coordinateMarks replaces the typographic degree, minute and second marks
+// with the ASCII forms matched by coordinatesRegexp
+var coordinateMarks = strings.NewReplacer(
+	"º", "°", "˚", "°",
+	"′", "'", "’", "'", "‘", "'", "´", "'",
+	"″", `"`, "”", `"`, "“", `"`, "''", `"`,
+)
+
+// componentPattern matches a latitude or longitude in decimal degrees,
+// degrees and decimal minutes or degrees, minutes and seconds, with an
+// optional hemisphere letter before or after it
+const componentPattern = `([NSEW])?\s*([-+]?\d+(?:\.\d+)?)` +
+	`(?:\s*°(?:\s*(\d+(?:\.\d+)?)\s*'(?:\s*(\d+(?:\.\d+)?)\s*")?)?)?` +
+	`\s*([NSEW])?`
+
+var coordinatesRegexp = regexp.MustCompile(`(?i)^` + componentPattern + `\s*(?:,|\s)\s*` + componentPattern + `$`)
+
+// CoordinatesParseError is returned by ParseCoordinates for input which is
+// not a valid pair of coordinates. It wraps ErrInvalidCoordinates,
+// ErrLatitudeOutOfRange or ErrLongitudeOutOfRange.
+type CoordinatesParseError struct {
+	Input string
+	Err   error
+}
+
+// Error ...
+func (e *CoordinatesParseError) Error() string {
+	return fmt.Sprintf("invalid coordinates %q: %s", e.Input, e.Err)
+}
+
+// Unwrap returns the reason the input is invalid
+func (e *CoordinatesParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseCoordinates parses coordinates written as:
+//
+//	51.520847,-0.195521 or 51.520847 -0.195521
+//	51.520847N 0.195521W or N51.520847 W0.195521
+//	51°31'15.0"N 0°11'43.9"W, in degrees, minutes and seconds
+//	51°31.25'N 0°11.732'W, in degrees and decimal minutes
+//	geo:51.520847,-0.195521, a geo URI as described by RFC 5870
+//
+// The latitude comes first unless hemisphere letters show otherwise.
+// Invalid input returns a *CoordinatesParseError.
+func ParseCoordinates(s string) (LatLng, error) {
+	var (
+		c   Coordinates
+		err error
+	)
+
+	trimmed := strings.TrimSpace(s)
+	if len(trimmed) >= len(geoScheme) && strings.EqualFold(trimmed[:len(geoScheme)], geoScheme) {
+		c, err = parseGeoURI(trimmed[len(geoScheme):])
+	} else {
+		c, err = parseNotation(coordinateMarks.Replace(trimmed))
+	}
+	if err == nil {
+		err = c.Validate()
+	}
+	if err != nil {
+		return LatLng{}, &CoordinatesParseError{Input: s, Err: err}
+	}
+
+	return c, nil
+}
+
+// parseGeoURI parses the path of a geo URI, which is the latitude, longitude
+// and optional altitude followed by parameters
+func parseGeoURI(path string) (LatLng, error) {
+	path = path[:indexOrLen(path, "?")]
+
+	params := strings.Split(path, ";")
+	for _, p := range params[1:] {
+		// other coordinate reference systems are not supported
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) == 2 && strings.EqualFold(kv[0], "crs") && !strings.EqualFold(kv[1], "wgs84") {
+			return LatLng{}, ErrInvalidCoordinates
+		}
+	}
+
+	parts := strings.Split(params[0], ",")
+	if len(parts) != 2 && len(parts) != 3 {
+		return LatLng{}, ErrInvalidCoordinates
+	}
+
+	lat, latErr := strconv.ParseFloat(parts[0], 64)
+	lng, lngErr := strconv.ParseFloat(parts[1], 64)
+	if latErr != nil || lngErr != nil {
+		return LatLng{}, ErrInvalidCoordinates
+	}
+
+	return LatLng{Lat: lat, Lng: lng}, nil
+}
+
+// parseNotation parses a latitude and longitude matched by coordinatesRegexp
+func parseNotation(s string) (LatLng, error) {
+	m := coordinatesRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return LatLng{}, ErrInvalidCoordinates
+	}
+
+	first, firstHemisphere, err := parseComponent(m[1:6])
+	if err != nil {
+		return LatLng{}, err
+	}
+
+	second, secondHemisphere, err := parseComponent(m[6:11])
+	if err != nil {
+		return LatLng{}, err
+	}
+
+	firstIsLng := firstHemisphere == 'E' || firstHemisphere == 'W' ||
+		secondHemisphere == 'N' || secondHemisphere == 'S'
+	firstIsLat := firstHemisphere == 'N' || firstHemisphere == 'S' ||
+		secondHemisphere == 'E' || secondHemisphere == 'W'
+
+	switch {
+	case firstIsLng && firstIsLat:
+		return LatLng{}, ErrInvalidCoordinates
+	case firstIsLng:
+		return LatLng{Lat: second, Lng: first}, nil
+	}
+
+	return LatLng{Lat: first, Lng: second}, nil
+}
+
+// parseComponent returns the signed value in degrees and the hemisphere, if
+// any, of a match of componentPattern given as its prefix, degrees, minutes,
+// seconds and suffix groups
+func parseComponent(groups []string) (float64, byte, error) {
+	prefix, degrees, minutes, seconds, suffix := groups[0], groups[1], groups[2], groups[3], groups[4]
+
+	if prefix != "" && suffix != "" {
+		return 0, 0, ErrInvalidCoordinates
+	}
+
+	var hemisphere byte
+	if h := prefix + suffix; h != "" {
+		hemisphere = strings.ToUpper(h)[0]
+	}
+
+	value, err := strconv.ParseFloat(degrees, 64)
+	if err != nil {
+		return 0, 0, ErrInvalidCoordinates
+	}
+
+	negative := strings.HasPrefix(degrees, "-")
+	if negative && hemisphere != 0 {
+		return 0, 0, ErrInvalidCoordinates
+	}
+
+	for _, part := range []struct {
+		value string
+		per   float64
+	}{
+		{minutes, 60},
+		{seconds, 3600},
+	} {
+		if part.value == "" {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(part.value, 64)
+		if err != nil || v >= 60 || strings.Contains(degrees, ".") {
+			return 0, 0, ErrInvalidCoordinates
+		}
+
+		v /= part.per
+		if negative {
+			value -= v
+		} else {
+			value += v
+		}
+	}
+
+	if hemisphere == 'S' || hemisphere == 'W' {
+		value = -value
+	}
+
+	return value, hemisphere, nil
+}
+
+// Validate returns ErrInvalidCoordinates if the latitude or longitude is NaN
+// or infinite, and ErrLatitudeOutOfRange or ErrLongitudeOutOfRange if c is
+// not a point on the globe
+func (c LatLng) Validate() error {
+	for _, v := range []float64{c.Lat, c.Lng} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return ErrInvalidCoordinates
+		}
+	}
+
+	if c.Lat < -90 || c.Lat > 90 {
+		return ErrLatitudeOutOfRange
+	}
+	if c.Lng < -180 || c.Lng > 180 {
+		return ErrLongitudeOutOfRange
+	}
+
+	return nil
+}
+
+// Clamp returns c with the latitude clamped to [-90, 90], such as for GPS
+// fixes which overshoot the poles
+func (c LatLng) Clamp() LatLng {
+	c.Lat = math.Max(-90, math.Min(90, c.Lat))
+
+	return c
+}
+
+// Normalise returns c with the longitude wrapped into [-180, 180], so 190 is
+// returned as -170. Longitudes already in range are unchanged.
+func (c LatLng) Normalise() LatLng {
+	if c.Lng >= -180 && c.Lng <= 180 {
+		return c
+	}
+
+	c.Lng = math.Mod(c.Lng+180, 360)
+	if c.Lng < 0 {
+		c.Lng += 360
+	}
+	c.Lng -= 180
+
+	return c
+}
+
+// String returns c as lat,lng, with each value in the shortest form which
+// parses back to it exactly
+func (c LatLng) String() string {
+	return formatDegrees(c.Lat) + "," + formatDegrees(c.Lng)
+}
+
+func formatDegrees(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
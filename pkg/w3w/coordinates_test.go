@@ -0,0 +1,221 @@
+package w3w_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCoordinates(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		input string
+
+		expectedCoords w3w.Coordinates
+		expectedErr    error
+	}{
+		{
+			desc:  "given comma separated coordinates, coordinates returned",
+			input: "51.520847,-0.195521",
+
+			expectedCoords: w3w.Coordinates{Lat: 51.520847, Lng: -0.195521},
+		},
+		{
+			desc:  "given space separated coordinates, coordinates returned",
+			input: " 51.520847  -0.195521 ",
+
+			expectedCoords: w3w.Coordinates{Lat: 51.520847, Lng: -0.195521},
+		},
+		{
+			desc:  "given hemisphere suffixes, signed coordinates returned",
+			input: "51.520847N, 0.195521W",
+
+			expectedCoords: w3w.Coordinates{Lat: 51.520847, Lng: -0.195521},
+		},
+		{
+			desc:  "given hemisphere prefixes, signed coordinates returned",
+			input: "s33.856784 e151.215297",
+
+			expectedCoords: w3w.Coordinates{Lat: -33.856784, Lng: 151.215297},
+		},
+		{
+			desc:  "given the longitude first with hemispheres, coordinates returned",
+			input: "0.195521 W 51.520847 N",
+
+			expectedCoords: w3w.Coordinates{Lat: 51.520847, Lng: -0.195521},
+		},
+		{
+			desc:  "given degrees, minutes and seconds, coordinates returned",
+			input: `51°31'15.0"N 0°11'43.9"W`,
+
+			expectedCoords: w3w.Coordinates{Lat: 51 + 31.0/60 + 15.0/3600, Lng: -(11.0/60 + 43.9/3600)},
+		},
+		{
+			desc:  "given degrees, minutes and seconds with typographic marks, coordinates returned",
+			input: "51° 31′ 15″ N, 0° 11′ 43.9″ W",
+
+			expectedCoords: w3w.Coordinates{Lat: 51 + 31.0/60 + 15.0/3600, Lng: -(11.0/60 + 43.9/3600)},
+		},
+		{
+			desc:  "given negative degrees and decimal minutes, coordinates returned",
+			input: "51°31.25' -0°11.5'",
+
+			expectedCoords: w3w.Coordinates{Lat: 51 + 31.25/60, Lng: -11.5 / 60},
+		},
+		{
+			desc:  "given a geo URI, coordinates returned",
+			input: "geo:51.520847,-0.195521,12;u=35",
+
+			expectedCoords: w3w.Coordinates{Lat: 51.520847, Lng: -0.195521},
+		},
+		{
+			desc:  "given a geo URI with another coordinate reference system, ErrInvalidCoordinates returned",
+			input: "GEO:51.520847,-0.195521;crs=nad27",
+
+			expectedErr: w3w.ErrInvalidCoordinates,
+		},
+		{
+			desc:  "given a single number, ErrInvalidCoordinates returned",
+			input: "51.520847",
+
+			expectedErr: w3w.ErrInvalidCoordinates,
+		},
+		{
+			desc:  "given a sign and a hemisphere, ErrInvalidCoordinates returned",
+			input: "-51.520847S 0.195521W",
+
+			expectedErr: w3w.ErrInvalidCoordinates,
+		},
+		{
+			desc:  "given two latitudes, ErrInvalidCoordinates returned",
+			input: "51.520847N 0.195521S",
+
+			expectedErr: w3w.ErrInvalidCoordinates,
+		},
+		{
+			desc:  "given 60 minutes, ErrInvalidCoordinates returned",
+			input: `51°60'N 0°11'W`,
+
+			expectedErr: w3w.ErrInvalidCoordinates,
+		},
+		{
+			desc:  "given an out of range latitude, ErrLatitudeOutOfRange returned",
+			input: "91,0",
+
+			expectedErr: w3w.ErrLatitudeOutOfRange,
+		},
+		{
+			desc:  "given an out of range longitude, ErrLongitudeOutOfRange returned",
+			input: "geo:0,180.5",
+
+			expectedErr: w3w.ErrLongitudeOutOfRange,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			coords, err := w3w.ParseCoordinates(tt.input)
+
+			if tt.expectedErr != nil {
+				var parseErr *w3w.CoordinatesParseError
+				assert.True(t, errors.As(err, &parseErr))
+				assert.Equal(t, tt.input, parseErr.Input)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+			} else {
+				assert.Nil(t, err)
+				assert.InDelta(t, tt.expectedCoords.Lat, coords.Lat, 1e-9)
+				assert.InDelta(t, tt.expectedCoords.Lng, coords.Lng, 1e-9)
+			}
+		})
+	}
+}
+
+func TestCoordinatesValidate(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		coords w3w.Coordinates
+
+		expectedErr error
+	}{
+		{
+			desc:   "given coordinates on the globe, nil returned",
+			coords: w3w.Coordinates{Lat: -90, Lng: 180},
+		},
+		{
+			desc:   "given a NaN longitude, ErrInvalidCoordinates returned",
+			coords: w3w.Coordinates{Lat: 0, Lng: math.NaN()},
+
+			expectedErr: w3w.ErrInvalidCoordinates,
+		},
+		{
+			desc:   "given an infinite latitude, ErrInvalidCoordinates returned",
+			coords: w3w.Coordinates{Lat: math.Inf(1), Lng: 0},
+
+			expectedErr: w3w.ErrInvalidCoordinates,
+		},
+		{
+			desc:   "given a latitude beyond a pole, ErrLatitudeOutOfRange returned",
+			coords: w3w.Coordinates{Lat: -90.5, Lng: 0},
+
+			expectedErr: w3w.ErrLatitudeOutOfRange,
+		},
+		{
+			desc:   "given a longitude beyond the antimeridian, ErrLongitudeOutOfRange returned",
+			coords: w3w.Coordinates{Lat: 0, Lng: 180.5},
+
+			expectedErr: w3w.ErrLongitudeOutOfRange,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expectedErr, tt.coords.Validate())
+		})
+	}
+}
+
+func TestCoordinatesNormalise(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		coords w3w.Coordinates
+
+		expectedClamped    w3w.Coordinates
+		expectedNormalised w3w.Coordinates
+	}{
+		{
+			desc:   "given coordinates in range, coordinates returned unchanged",
+			coords: w3w.Coordinates{Lat: 51.520847, Lng: 180},
+
+			expectedClamped:    w3w.Coordinates{Lat: 51.520847, Lng: 180},
+			expectedNormalised: w3w.Coordinates{Lat: 51.520847, Lng: 180},
+		},
+		{
+			desc:   "given a longitude east of the antimeridian, longitude wrapped",
+			coords: w3w.Coordinates{Lat: 91, Lng: 190},
+
+			expectedClamped:    w3w.Coordinates{Lat: 90, Lng: 190},
+			expectedNormalised: w3w.Coordinates{Lat: 91, Lng: -170},
+		},
+		{
+			desc:   "given a longitude several turns west, longitude wrapped",
+			coords: w3w.Coordinates{Lat: -95, Lng: -900},
+
+			expectedClamped:    w3w.Coordinates{Lat: -90, Lng: -900},
+			expectedNormalised: w3w.Coordinates{Lat: -95, Lng: -180},
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expectedClamped, tt.coords.Clamp())
+			assert.Equal(t, tt.expectedNormalised, tt.coords.Normalise())
+		})
+	}
+}
+
+func TestCoordinatesString(t *testing.T) {
+	assert.Equal(t, "51.52084736,-0.1955213", w3w.Coordinates{Lat: 51.52084736, Lng: -0.1955213}.String())
+}
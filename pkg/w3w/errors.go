@@ -11,8 +11,22 @@ var (
 	ErrNoAPIKey = fmt.Errorf("invalid or empty API Key provided")
 	// ErrEmptyWord ...
 	ErrEmptyWord = fmt.Errorf("an empty words was provided")
+	// ErrInvalidWord ...
+	ErrInvalidWord = fmt.Errorf("an invalid word was provided")
 	// ErrInvalidNumberOfWords ...
 	ErrInvalidNumberOfWords = fmt.Errorf("invalid number of words provided")
+	// ErrInvalidCoordinates ...
+	ErrInvalidCoordinates = fmt.Errorf("invalid coordinates provided")
+	// ErrLatitudeOutOfRange ...
+	ErrLatitudeOutOfRange = fmt.Errorf("latitude must be between -90 and 90")
+	// ErrLongitudeOutOfRange ...
+	ErrLongitudeOutOfRange = fmt.Errorf("longitude must be between -180 and 180")
+	// ErrEmptyInput ...
+	ErrEmptyInput = fmt.Errorf("an empty input was provided")
+	// ErrInvalidBoundingBox ...
+	ErrInvalidBoundingBox = fmt.Errorf("invalid bounding box provided")
+	// ErrInvalidOption ...
+	ErrInvalidOption = fmt.Errorf("invalid option provided")
 )
 
 // Error ...
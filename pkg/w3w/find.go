@@ -0,0 +1,122 @@
+package w3w
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// badWordsCode is the error code the API returns for addresses which do not
+// exist
+const badWordsCode = "BadWords"
+
+// findRegexp matches runs of dotted words in text. Runs of other than 3
+// words are discarded by FindPossible3wa, so an address is not found inside
+// a longer run such as a.b.c.d. Only single word parts are matched, as
+// addresses of several syllables can't be told apart from sentences.
+var findRegexp = regexp.MustCompile(`/*` + wordPattern + `(?:` + separatorPattern + wordPattern + `)+`)
+
+// Candidate is a possible 3 word address found in text
+type Candidate struct {
+	// Text is the address as written, including any /// prefix
+	Text string
+	// Start and End are the byte offsets of Text
+	Start int
+	End   int
+	Words Words
+}
+
+// Verified is a Candidate which resolved to a 3 word address
+type Verified struct {
+	Candidate
+	Result Result
+}
+
+// FindPossible3wa returns the possible 3 word addresses in text, such as
+// ///index.home.raft in "I'm at ///index.home.raft near the gate", in the
+// order they appear. It does not call the API, see VerifyPossible3wa.
+func FindPossible3wa(text string) []Candidate {
+	var candidates []Candidate
+	for _, loc := range findRegexp.FindAllStringIndex(text, -1) {
+		// only a /// prefix is part of the address, other slashes are
+		// usually the end of a link's path
+		if trimmed := strings.TrimLeft(text[loc[0]:loc[1]], "/"); !strings.HasPrefix(text[loc[0]:], wordsPrefix+trimmed) {
+			loc[0] = loc[1] - len(trimmed)
+		}
+
+		words, err := ParseWords(text[loc[0]:loc[1]])
+		if err != nil {
+			continue
+		}
+
+		candidates = append(candidates, Candidate{
+			Text:  text[loc[0]:loc[1]],
+			Start: loc[0],
+			End:   loc[1],
+			Words: words,
+		})
+	}
+
+	return candidates
+}
+
+// VerifyOptions configures VerifyPossible3wa
+type VerifyOptions struct {
+	// Workers sets the number of addresses resolved concurrently, defaulting
+	// to 4
+	Workers int
+	// Options are used for each conversion
+	Options CoordinateOptions
+}
+
+// VerifyPossible3wa resolves the candidates through the converter, returning
+// those which are real 3 word addresses in their original order. Candidates
+// the API rejects as BadWords are dropped, while any other error, such as
+// an invalid key, is returned. Repeated addresses are resolved once.
+func VerifyPossible3wa(ctx context.Context, conv CoordinatesConverter, candidates []Candidate, opts VerifyOptions) ([]Verified, error) {
+	if opts.Workers < 1 {
+		opts.Workers = defaultWorkers
+	}
+
+	type resolution struct {
+		res Result
+		ok  bool
+	}
+
+	var unique []Words
+	resolved := map[Words]*resolution{}
+	for _, c := range candidates {
+		if _, ok := resolved[c.Words]; !ok {
+			resolved[c.Words] = &resolution{}
+			unique = append(unique, c.Words)
+		}
+	}
+
+	err := forEach(ctx, len(unique), opts.Workers, func(ctx context.Context, i int) error {
+		res, err := conv.GetCoordinatesContext(ctx, unique[i], opts.Options)
+		if err == nil {
+			*resolved[unique[i]] = resolution{res: res, ok: true}
+			return nil
+		}
+
+		var apiErr Error
+		if errors.As(err, &apiErr) && apiErr.Code == badWordsCode {
+			return nil
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var verified []Verified
+	for _, c := range candidates {
+		if r := resolved[c.Words]; r.ok {
+			verified = append(verified, Verified{Candidate: c, Result: r.res})
+		}
+	}
+
+	return verified, nil
+}
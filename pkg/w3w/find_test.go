@@ -0,0 +1,127 @@
+package w3w_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindPossible3wa(t *testing.T) {
+	testCases := []struct {
+		desc string
+		text string
+
+		expectedCandidates []w3w.Candidate
+	}{
+		{
+			desc: "given an address in a sentence, the address and its offsets returned",
+			text: "I'm at ///index.home.raft near the gate",
+
+			expectedCandidates: []w3w.Candidate{
+				{Text: "///index.home.raft", Start: 7, End: 25, Words: w3w.Words{"index", "home", "raft"}},
+			},
+		},
+		{
+			desc: "given several addresses ending sentences, every address returned",
+			text: "Meet at filled.count.soap. Or at こくさい。ていか。かざす。",
+
+			expectedCandidates: []w3w.Candidate{
+				{Text: "filled.count.soap", Start: 8, End: 25, Words: w3w.Words{"filled", "count", "soap"}},
+				{Text: "こくさい。ていか。かざす", Start: 33, End: 69, Words: w3w.Words{"こくさい", "ていか", "かざす"}},
+			},
+		},
+		{
+			desc: "given an address in a link, the address returned",
+			text: "see https://w3w.co/Filled.Count.Soap",
+
+			expectedCandidates: []w3w.Candidate{
+				{Text: "Filled.Count.Soap", Start: 19, End: 36, Words: w3w.Words{"filled", "count", "soap"}},
+			},
+		},
+		{
+			desc: "given a run of four words, nothing returned",
+			text: "one.two.three.four",
+		},
+		{
+			desc: "given words containing digits, nothing returned",
+			text: "version 1.2.3 of foo.bar2.baz",
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			candidates := w3w.FindPossible3wa(tt.text)
+
+			assert.Equal(t, tt.expectedCandidates, candidates)
+			for _, c := range candidates {
+				assert.Equal(t, c.Text, tt.text[c.Start:c.End])
+			}
+		})
+	}
+}
+
+func TestVerifyPossible3wa(t *testing.T) {
+	text := "at filled.count.soap, not index.home.raft, again ///filled.count.soap"
+
+	testCases := []struct {
+		desc     string
+		apiError string
+
+		expectedTexts    []string
+		expectedRequests int
+		expectedErr      error
+	}{
+		{
+			desc: "given real and unknown addresses, real addresses returned in order",
+
+			expectedTexts:    []string{"filled.count.soap", "///filled.count.soap"},
+			expectedRequests: 2,
+		},
+		{
+			desc:     "given the API rejects the key, error returned",
+			apiError: w3wtest.InvalidKey,
+
+			expectedErr: w3w.Error{Code: w3wtest.InvalidKey},
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+			defer s.Close()
+
+			if tt.apiError != "" {
+				s.InjectError("", tt.apiError, 0)
+			}
+
+			c, err := w3w.New("foobar")
+			assert.Nil(t, err)
+
+			verified, err := w3w.VerifyPossible3wa(context.Background(), c, w3w.FindPossible3wa(text), w3w.VerifyOptions{
+				Options: w3w.CoordinateOptions{APIURL: s.URL},
+			})
+
+			if tt.expectedErr != nil {
+				var apiErr w3w.Error
+				assert.True(t, errors.As(err, &apiErr))
+				assert.Equal(t, tt.apiError, apiErr.Code)
+				assert.Nil(t, verified)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Len(t, s.Requests(), tt.expectedRequests)
+
+			var texts []string
+			for _, v := range verified {
+				texts = append(texts, v.Text)
+				assert.Equal(t, w3wtest.FilledCountSoap, v.Result)
+			}
+			assert.Equal(t, tt.expectedTexts, texts)
+		})
+	}
+}
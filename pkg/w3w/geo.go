@@ -0,0 +1,153 @@
+package w3w
+
+import (
+	"errors"
+	"math"
+)
+
+// The WGS84 ellipsoid used by Vincenty's formulae
+const (
+	wgs84SemiMajorAxis = 6378137.0
+	wgs84Flattening    = 1 / 298.257223563
+	wgs84SemiMinorAxis = (1 - wgs84Flattening) * wgs84SemiMajorAxis
+
+	vincentyIterations = 200
+	vincentyTolerance  = 1e-12
+)
+
+// ErrNoConvergence is returned by VincentyDistanceTo for nearly antipodal
+// points, for which Vincenty's formulae fail to converge
+var ErrNoConvergence = errors.New("vincenty formula failed to converge")
+
+// DistanceTo returns the great circle distance to q in metres, using the
+// haversine formula on a spherical Earth. It is within about 0.5% of the
+// distance on the ellipsoid, see VincentyDistanceTo.
+func (p LatLng) DistanceTo(q LatLng) float64 {
+	phi1, phi2 := radians(p.Lat), radians(q.Lat)
+	dPhi, dLambda := radians(q.Lat-p.Lat), radians(q.Lng-p.Lng)
+
+	h := math.Sin(dPhi/2)*math.Sin(dPhi/2) + math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+
+	return 2 * earthRadiusMeters * math.Atan2(math.Sqrt(h), math.Sqrt(1-h))
+}
+
+// VincentyDistanceTo returns the distance to q in metres on the WGS84
+// ellipsoid, accurate to within a millimetre, using Vincenty's inverse
+// formula. ErrNoConvergence is returned for nearly antipodal points.
+func (p LatLng) VincentyDistanceTo(q LatLng) (float64, error) {
+	const a, b, f = wgs84SemiMajorAxis, wgs84SemiMinorAxis, wgs84Flattening
+
+	L := radians(q.Lng - p.Lng)
+	U1 := math.Atan((1 - f) * math.Tan(radians(p.Lat)))
+	U2 := math.Atan((1 - f) * math.Tan(radians(q.Lat)))
+	sinU1, cosU1 := math.Sincos(U1)
+	sinU2, cosU2 := math.Sincos(U2)
+
+	var sinSigma, cosSigma, sigma, cos2Alpha, cos2SigmaM float64
+
+	lambda := L
+	for i := 0; ; i++ {
+		if i == vincentyIterations {
+			return 0, ErrNoConvergence
+		}
+
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			// the points coincide
+			return 0, nil
+		}
+
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha = 1 - sinAlpha*sinAlpha
+
+		// cos2Alpha is 0 for points on the equator
+		cos2SigmaM = 0
+		if cos2Alpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+
+		C := f / 16 * cos2Alpha * (4 + f*(4-3*cos2Alpha))
+		prev := lambda
+		lambda = L + (1-C)*f*sinAlpha*(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+		if math.Abs(lambda-prev) < vincentyTolerance {
+			break
+		}
+	}
+
+	u2 := cos2Alpha * (a*a - b*b) / (b * b)
+	A := 1 + u2/16384*(4096+u2*(-768+u2*(320-175*u2)))
+	B := u2 / 1024 * (256 + u2*(-128+u2*(74-47*u2)))
+	dSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+	return b * A * (sigma - dSigma), nil
+}
+
+// BearingTo returns the initial bearing of the great circle path to q, in
+// degrees clockwise from north in [0, 360)
+func (p LatLng) BearingTo(q LatLng) float64 {
+	phi1, phi2 := radians(p.Lat), radians(q.Lat)
+	dLambda := radians(q.Lng - p.Lng)
+
+	y := math.Sin(dLambda) * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(dLambda)
+
+	return math.Mod(degrees(math.Atan2(y, x))+360, 360)
+}
+
+// MidpointTo returns the point halfway along the great circle path to q
+func (p LatLng) MidpointTo(q LatLng) LatLng {
+	phi1, phi2 := radians(p.Lat), radians(q.Lat)
+	dLambda := radians(q.Lng - p.Lng)
+
+	bx := math.Cos(phi2) * math.Cos(dLambda)
+	by := math.Cos(phi2) * math.Sin(dLambda)
+
+	phiM := math.Atan2(math.Sin(phi1)+math.Sin(phi2), math.Hypot(math.Cos(phi1)+bx, by))
+	lambdaM := radians(p.Lng) + math.Atan2(by, math.Cos(phi1)+bx)
+
+	return LatLng{Lat: degrees(phiM), Lng: degrees(lambdaM)}.Normalise()
+}
+
+// Destination returns the point reached by travelling meters along the
+// great circle from p with the initial bearing, in degrees clockwise from
+// north
+func (p LatLng) Destination(bearing, meters float64) LatLng {
+	phi1, lambda1 := radians(p.Lat), radians(p.Lng)
+	theta := radians(bearing)
+	delta := meters / earthRadiusMeters
+
+	phi2 := math.Asin(math.Sin(phi1)*math.Cos(delta) + math.Cos(phi1)*math.Sin(delta)*math.Cos(theta))
+	lambda2 := lambda1 + math.Atan2(math.Sin(theta)*math.Sin(delta)*math.Cos(phi1), math.Cos(delta)-math.Sin(phi1)*math.Sin(phi2))
+
+	return LatLng{Lat: degrees(phi2), Lng: degrees(lambda2)}.Normalise()
+}
+
+// DistanceTo returns the haversine distance in metres between the centres
+// of the results' squares
+func (r Result) DistanceTo(o Result) float64 {
+	return r.Coordinates.DistanceTo(o.Coordinates)
+}
+
+// VincentyDistanceTo returns the distance in metres on the WGS84 ellipsoid
+// between the centres of the results' squares
+func (r Result) VincentyDistanceTo(o Result) (float64, error) {
+	return r.Coordinates.VincentyDistanceTo(o.Coordinates)
+}
+
+// BearingTo returns the initial bearing, in degrees clockwise from north,
+// from the centre of r's square to the centre of o's
+func (r Result) BearingTo(o Result) float64 {
+	return r.Coordinates.BearingTo(o.Coordinates)
+}
+
+// MidpointTo returns the point halfway between the centres of the results'
+// squares
+func (r Result) MidpointTo(o Result) LatLng {
+	return r.Coordinates.MidpointTo(o.Coordinates)
+}
@@ -0,0 +1,131 @@
+package w3w_test
+
+import (
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/stretchr/testify/assert"
+)
+
+// flindersPeak and buninyong are the points of the worked example in
+// Vincenty's 1975 paper
+var (
+	flindersPeak = w3w.LatLng{Lat: -(37 + 57/60.0 + 3.72030/3600), Lng: 144 + 25/60.0 + 29.52440/3600}
+	buninyong    = w3w.LatLng{Lat: -(37 + 39/60.0 + 10.15610/3600), Lng: 143 + 55/60.0 + 35.38390/3600}
+)
+
+func TestDistanceTo(t *testing.T) {
+	testCases := []struct {
+		desc string
+		p, q w3w.LatLng
+
+		expectedHaversine float64
+		expectedVincenty  float64
+		expectedErr       error
+	}{
+		{
+			desc: "given Vincenty's example, distances returned",
+			p:    flindersPeak,
+			q:    buninyong,
+
+			expectedHaversine: 54925.508,
+			expectedVincenty:  54972.271,
+		},
+		{
+			desc: "given the same point, 0 returned",
+			p:    flindersPeak,
+			q:    flindersPeak,
+		},
+		{
+			desc: "given points along the equator, distances returned",
+			p:    w3w.LatLng{Lat: 0, Lng: 0},
+			q:    w3w.LatLng{Lat: 0, Lng: 1},
+
+			expectedHaversine: 111195.080,
+			expectedVincenty:  111319.491,
+		},
+		{
+			desc: "given antipodal points, ErrNoConvergence returned",
+			p:    w3w.LatLng{Lat: 0, Lng: 0},
+			q:    w3w.LatLng{Lat: 0.5, Lng: 179.7},
+
+			expectedHaversine: 19950277.343,
+			expectedErr:       w3w.ErrNoConvergence,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.InDelta(t, tt.expectedHaversine, tt.p.DistanceTo(tt.q), 0.001)
+
+			d, err := tt.p.VincentyDistanceTo(tt.q)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.InDelta(t, tt.expectedVincenty, d, 0.001)
+		})
+	}
+}
+
+func TestBearingTo(t *testing.T) {
+	testCases := []struct {
+		desc string
+		p, q w3w.LatLng
+
+		expected float64
+	}{
+		{
+			desc:     "given a point due east, 90 returned",
+			p:        w3w.LatLng{Lat: 0, Lng: 0},
+			q:        w3w.LatLng{Lat: 0, Lng: 1},
+			expected: 90,
+		},
+		{
+			desc:     "given a point due south, 180 returned",
+			p:        w3w.LatLng{Lat: 10, Lng: 20},
+			q:        w3w.LatLng{Lat: -10, Lng: 20},
+			expected: 180,
+		},
+		{
+			// on the ellipsoid the bearing is 306.8682
+			desc:     "given Vincenty's example, initial bearing on a sphere returned",
+			p:        flindersPeak,
+			q:        buninyong,
+			expected: 306.9839,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, tt.p.BearingTo(tt.q), 0.01)
+		})
+	}
+}
+
+func TestMidpointAndDestination(t *testing.T) {
+	mid := flindersPeak.MidpointTo(buninyong)
+	assert.InDelta(t, mid.DistanceTo(flindersPeak), mid.DistanceTo(buninyong), 0.001)
+	assert.InDelta(t, flindersPeak.DistanceTo(buninyong)/2, mid.DistanceTo(buninyong), 0.001)
+
+	dest := flindersPeak.Destination(flindersPeak.BearingTo(buninyong), flindersPeak.DistanceTo(buninyong))
+	assert.InDelta(t, buninyong.Lat, dest.Lat, 1e-9)
+	assert.InDelta(t, buninyong.Lng, dest.Lng, 1e-9)
+
+	// crossing the antimeridian wraps the longitude
+	dest = w3w.LatLng{Lat: 0, Lng: 179.5}.Destination(90, w3w.LatLng{Lat: 0, Lng: 0}.DistanceTo(w3w.LatLng{Lat: 0, Lng: 1}))
+	assert.InDelta(t, -179.5, dest.Lng, 1e-9)
+
+	mid = w3w.LatLng{Lat: 0, Lng: 179}.MidpointTo(w3w.LatLng{Lat: 0, Lng: -179})
+	assert.InDelta(t, 180, mid.Lng, 1e-9)
+}
+
+func TestResultGeometry(t *testing.T) {
+	from := w3w.Result{Words: "a.b.c", Coordinates: flindersPeak}
+	to := w3w.Result{Words: "x.y.z", Coordinates: buninyong}
+
+	assert.Equal(t, flindersPeak.DistanceTo(buninyong), from.DistanceTo(to))
+	assert.Equal(t, flindersPeak.BearingTo(buninyong), from.BearingTo(to))
+	assert.Equal(t, flindersPeak.MidpointTo(buninyong), from.MidpointTo(to))
+
+	d, err := from.VincentyDistanceTo(to)
+	assert.Nil(t, err)
+	assert.InDelta(t, 54972.271, d, 0.001)
+}
@@ -0,0 +1,92 @@
+package w3w
+
+import (
+	"context"
+
+	"github.com/jonnypillar/what3words/internal/api"
+)
+
+const (
+	gridSectionRoute = "grid-section"
+
+	paramBoundingBox = "bounding-box"
+)
+
+// Line is a line of the what3words grid
+type Line struct {
+	Start LatLng `json:"start"`
+	End   LatLng `json:"end"`
+}
+
+// GridSectionOptions ...
+type GridSectionOptions struct {
+	APIURL string
+	// Key overrides the client's API key for the call
+	Key string
+}
+
+// gridSectionResponse defines the grid-section response body
+type gridSectionResponse struct {
+	Lines []Line `json:"lines"`
+}
+
+// GridSection returns the lines of the what3words grid within box, for
+// drawing the grid on a map. The API rejects boxes with a diagonal over 4km.
+func (c Client) GridSection(box Square, opts GridSectionOptions) ([]Line, error) {
+	return c.GridSectionContext(context.Background(), box, opts)
+}
+
+// GridSectionContext is GridSection with a context, which cancels the request when done
+func (c Client) GridSectionContext(ctx context.Context, box Square, opts GridSectionOptions) ([]Line, error) {
+	err := box.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := c.selectKey(ctx, opts.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := api.NewURL(key, opts.APIURL, gridSectionRoute)
+	if err != nil {
+		return nil, err
+	}
+
+	url.AddParam(paramBoundingBox, box.param())
+	url.AddParam(paramFormat, formatJSON)
+
+	var resp gridSectionResponse
+
+	err = c.call(ctx, key, url.URL(), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Lines, nil
+}
+
+// validate checks both corners are valid and the south west corner is not
+// north of the north east corner. The box may cross the antimeridian.
+func (s Square) validate() error {
+	err := s.Southwest.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = s.Northeast.Validate()
+	if err != nil {
+		return err
+	}
+
+	if s.Southwest.Lat > s.Northeast.Lat {
+		return ErrInvalidBoundingBox
+	}
+
+	return nil
+}
+
+// param returns the square in the API's south,west,north,east form
+func (s Square) param() string {
+	return s.Southwest.String() + "," + s.Northeast.String()
+}
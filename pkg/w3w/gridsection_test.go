@@ -0,0 +1,137 @@
+package w3w_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGridSection(t *testing.T) {
+	sq := w3wtest.FilledCountSoap.Square
+
+	testCases := []struct {
+		desc     string
+		box      w3w.Square
+		apiError string
+
+		expectedLines    []w3w.Line
+		expectedParam    string
+		expectedRequests int
+		expectedErr      error
+	}{
+		{
+			desc: "given a box around a fixture, the fixture's edges returned",
+			box:  w3w.Square{Southwest: w3w.LatLng{Lat: 51.52, Lng: -0.196}, Northeast: w3w.LatLng{Lat: 51.521, Lng: -0.195}},
+
+			expectedLines: []w3w.Line{
+				{Start: sq.Southwest, End: w3w.LatLng{Lat: sq.Southwest.Lat, Lng: sq.Northeast.Lng}},
+				{Start: w3w.LatLng{Lat: sq.Southwest.Lat, Lng: sq.Northeast.Lng}, End: sq.Northeast},
+				{Start: sq.Northeast, End: w3w.LatLng{Lat: sq.Northeast.Lat, Lng: sq.Southwest.Lng}},
+				{Start: w3w.LatLng{Lat: sq.Northeast.Lat, Lng: sq.Southwest.Lng}, End: sq.Southwest},
+			},
+			expectedParam:    "51.52,-0.196,51.521,-0.195",
+			expectedRequests: 1,
+		},
+		{
+			desc: "given a box elsewhere, no lines returned",
+			box:  w3w.Square{Southwest: w3w.LatLng{Lat: 48.85, Lng: 2.29}, Northeast: w3w.LatLng{Lat: 48.86, Lng: 2.3}},
+
+			expectedLines:    []w3w.Line{},
+			expectedParam:    "48.85,2.29,48.86,2.3",
+			expectedRequests: 1,
+		},
+		{
+			desc: "given a box with its south west corner north of its north east corner, ErrInvalidBoundingBox returned",
+			box:  w3w.Square{Southwest: w3w.LatLng{Lat: 51.521, Lng: -0.196}, Northeast: w3w.LatLng{Lat: 51.52, Lng: -0.195}},
+
+			expectedErr: w3w.ErrInvalidBoundingBox,
+		},
+		{
+			desc: "given a corner out of range, ErrLongitudeOutOfRange returned",
+			box:  w3w.Square{Southwest: w3w.LatLng{Lat: 51.52, Lng: -181}, Northeast: w3w.LatLng{Lat: 51.521, Lng: -0.195}},
+
+			expectedErr: w3w.ErrLongitudeOutOfRange,
+		},
+		{
+			desc: "given a box too big for the API, BadBoundingBoxTooBig returned",
+			box:  w3w.Square{Southwest: w3w.LatLng{Lat: 51, Lng: -1}, Northeast: w3w.LatLng{Lat: 52, Lng: 0}},
+
+			expectedRequests: 1,
+			expectedErr:      w3w.Error{Code: w3wtest.BadBoundingBoxTooBig, Message: "The diagonal of bounding-box may not be more than 4km"},
+		},
+		{
+			desc:     "given the API returns an error, error returned",
+			box:      w3w.Square{Southwest: w3w.LatLng{Lat: 51.52, Lng: -0.196}, Northeast: w3w.LatLng{Lat: 51.521, Lng: -0.195}},
+			apiError: w3wtest.SuspendedKey,
+
+			expectedRequests: 1,
+			expectedErr:      w3w.Error{Code: w3wtest.SuspendedKey, Message: "Authentication failed; API key has been suspended"},
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+			defer s.Close()
+
+			if tt.apiError != "" {
+				s.InjectError(w3wtest.RouteGridSection, tt.apiError, 1)
+			}
+
+			c, err := w3w.New("foobar")
+			assert.Nil(t, err)
+
+			lines, err := c.GridSection(tt.box, w3w.GridSectionOptions{APIURL: s.URL})
+
+			reqs := s.Requests()
+			assert.Len(t, reqs, tt.expectedRequests)
+
+			if tt.expectedErr != nil {
+				assert.True(t, errors.Is(err, tt.expectedErr), "expected %v, got %v", tt.expectedErr, err)
+				return
+			}
+			assert.Nil(t, err)
+
+			assert.Equal(t, tt.expectedLines, lines)
+			assert.Equal(t, tt.expectedParam, reqs[0].Query().Get("bounding-box"))
+		})
+	}
+}
+
+func TestGridSectionGrid(t *testing.T) {
+	s := w3wtest.NewGridServer(42)
+	defer s.Close()
+
+	c, err := w3w.New("foobar")
+	assert.Nil(t, err)
+
+	res, err := c.GetWords(w3w.LatLng{Lat: 51.520847, Lng: -0.195521}, w3w.WordOptions{APIURL: s.URL})
+	assert.Nil(t, err)
+
+	lines, err := c.GridSection(res.Square.Expand(-0.1), w3w.GridSectionOptions{APIURL: s.URL})
+	assert.Nil(t, err)
+
+	// a box within a square crosses none of its lines
+	assert.Empty(t, lines)
+
+	lines, err = c.GridSection(res.Square.Expand(1), w3w.GridSectionOptions{APIURL: s.URL})
+	assert.Nil(t, err)
+
+	// every edge of the square lies on a returned line
+	lats, lngs := map[float64]bool{}, map[float64]bool{}
+	for _, l := range lines {
+		if l.Start.Lat == l.End.Lat {
+			lats[l.Start.Lat] = true
+		} else {
+			lngs[l.Start.Lng] = true
+		}
+	}
+
+	assert.True(t, lats[res.Square.Southwest.Lat])
+	assert.True(t, lats[res.Square.Northeast.Lat])
+	assert.True(t, lngs[res.Square.Southwest.Lng])
+	assert.True(t, lngs[res.Square.Northeast.Lng])
+}
@@ -0,0 +1,247 @@
+package w3w
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	keyIDLength = 12
+
+	defaultQuarantine = time.Hour
+)
+
+// ErrNoKeyAvailable is returned when every key in a KeyPool is quarantined
+var ErrNoKeyAvailable = fmt.Errorf("no API key available, every key in the pool is quarantined")
+
+// quarantineCodes are the error codes which quarantine a pooled key
+var quarantineCodes = map[string]bool{
+	"InvalidKey":    true,
+	"SuspendedKey":  true,
+	"QuotaExceeded": true,
+}
+
+type keyContextKey struct{}
+
+// ContextWithKey returns a copy of ctx which makes the client's Context
+// methods use key, for example a customer's own key. A key in the call's
+// options takes precedence.
+func ContextWithKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, keyContextKey{}, key)
+}
+
+// KeyFromContext returns the key set by ContextWithKey
+func KeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(keyContextKey{}).(string)
+
+	return key, ok && key != ""
+}
+
+// KeyID returns a stable identifier for key which is safe to log or use as
+// a metric label
+func KeyID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])[:keyIDLength]
+}
+
+// WithKeyPool makes the client take its key for each call from pool, when
+// the call's options or context do not set one. Keys returning InvalidKey,
+// SuspendedKey or QuotaExceeded errors are quarantined in the pool.
+func WithKeyPool(pool *KeyPool) Option {
+	return func(c *Client) {
+		c.keyPool = pool
+	}
+}
+
+// selectKey returns the key for a call, in order of precedence the key in
+// its options, its context, the client's key pool or the client's key
+func (c Client) selectKey(ctx context.Context, optKey string) (string, error) {
+	if optKey != "" {
+		return optKey, nil
+	}
+
+	if key, ok := KeyFromContext(ctx); ok {
+		return key, nil
+	}
+
+	if c.keyPool != nil {
+		return c.keyPool.Next()
+	}
+
+	return c.key, nil
+}
+
+// KeySelection is how a KeyPool picks the next key
+type KeySelection int
+
+// Key selections
+const (
+	// RoundRobin uses each available key in turn
+	RoundRobin KeySelection = iota
+	// LeastUsed uses the available key picked the fewest times
+	LeastUsed
+)
+
+// KeyPoolOptions configures a KeyPool
+type KeyPoolOptions struct {
+	Selection KeySelection
+	// QuarantineFor is how long a failing key is left out of the pool, an
+	// hour by default
+	QuarantineFor time.Duration
+}
+
+// KeyStatus describes a key in a KeyPool
+type KeyStatus struct {
+	KeyID string
+	Uses  int64
+	// QuarantinedUntil is zero when the key is available
+	QuarantinedUntil time.Time
+	// Reason is the error code which quarantined the key
+	Reason string
+}
+
+// KeyPool spreads calls across several API keys, leaving out keys which
+// fail with key or quota errors for a while. It is safe for concurrent use.
+type KeyPool struct {
+	opts KeyPoolOptions
+
+	mu   sync.Mutex
+	keys []*pooledKey
+	next int
+}
+
+type pooledKey struct {
+	key              string
+	uses             int64
+	quarantinedUntil time.Time
+	reason           string
+}
+
+// NewKeyPool initialises a new KeyPool, returning ErrNoAPIKey if no keys or
+// an empty key are provided
+func NewKeyPool(keys []string, opts KeyPoolOptions) (*KeyPool, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoAPIKey
+	}
+
+	if opts.QuarantineFor <= 0 {
+		opts.QuarantineFor = defaultQuarantine
+	}
+
+	p := &KeyPool{
+		opts: opts,
+	}
+
+	for _, key := range keys {
+		if strings.TrimSpace(key) == "" {
+			return nil, ErrNoAPIKey
+		}
+
+		p.keys = append(p.keys, &pooledKey{key: key})
+	}
+
+	return p, nil
+}
+
+// Next returns the next available key, or ErrNoKeyAvailable
+func (p *KeyPool) Next() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+
+	var picked *pooledKey
+	for i := range p.keys {
+		k := p.keys[(p.next+i)%len(p.keys)]
+		if !k.available(now) {
+			continue
+		}
+
+		if p.opts.Selection == RoundRobin {
+			picked = k
+			p.next = (p.next + i + 1) % len(p.keys)
+			break
+		}
+
+		if picked == nil || k.uses < picked.uses {
+			picked = k
+		}
+	}
+
+	if picked == nil {
+		return "", ErrNoKeyAvailable
+	}
+
+	picked.uses++
+
+	return picked.key, nil
+}
+
+// Quarantine leaves key out of the pool for the pool's QuarantineFor
+// duration, recording the reason
+func (p *KeyPool) Quarantine(key, reason string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, k := range p.keys {
+		if k.key == key {
+			k.quarantinedUntil = time.Now().Add(p.opts.QuarantineFor)
+			k.reason = reason
+		}
+	}
+}
+
+// Release returns a quarantined key to the pool
+func (p *KeyPool) Release(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, k := range p.keys {
+		if k.key == key {
+			k.quarantinedUntil = time.Time{}
+			k.reason = ""
+		}
+	}
+}
+
+// Status returns the status of each key in the pool, identified by KeyID
+func (p *KeyPool) Status() []KeyStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+
+	status := make([]KeyStatus, len(p.keys))
+	for i, k := range p.keys {
+		status[i] = KeyStatus{
+			KeyID: KeyID(k.key),
+			Uses:  k.uses,
+		}
+
+		if !k.available(now) {
+			status[i].QuarantinedUntil = k.quarantinedUntil
+			status[i].Reason = k.reason
+		}
+	}
+
+	return status
+}
+
+// report quarantines key if err is a key or quota error
+func (p *KeyPool) report(key string, err error) {
+	var w3wErr Error
+	if errors.As(err, &w3wErr) && quarantineCodes[w3wErr.Code] {
+		p.Quarantine(key, w3wErr.Code)
+	}
+}
+
+func (k *pooledKey) available(now time.Time) bool {
+	return !now.Before(k.quarantinedUntil)
+}
@@ -0,0 +1,193 @@
+package w3w_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyOverride(t *testing.T) {
+	testCases := []struct {
+		desc string
+		ctx  context.Context
+		key  string
+
+		expectedKey string
+	}{
+		{
+			desc: "given no override, client key used",
+			ctx:  context.Background(),
+
+			expectedKey: "client-key",
+		},
+		{
+			desc: "given a key in the context, context key used",
+			ctx:  w3w.ContextWithKey(context.Background(), "context-key"),
+
+			expectedKey: "context-key",
+		},
+		{
+			desc: "given a key in the options and context, options key used",
+			ctx:  w3w.ContextWithKey(context.Background(), "context-key"),
+			key:  "options-key",
+
+			expectedKey: "options-key",
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+			defer s.Close()
+
+			c, err := w3w.New("client-key")
+			assert.Nil(t, err)
+
+			_, err = c.GetCoordinatesContext(tt.ctx, w3w.Words{"filled", "count", "soap"}, w3w.CoordinateOptions{
+				APIURL: s.URL,
+				Key:    tt.key,
+			})
+			assert.Nil(t, err)
+
+			reqs := s.Requests()
+			assert.Len(t, reqs, 1)
+			assert.Equal(t, tt.expectedKey, reqs[0].Query().Get("key"))
+		})
+	}
+}
+
+func TestNewKeyPool(t *testing.T) {
+	_, err := w3w.NewKeyPool(nil, w3w.KeyPoolOptions{})
+	assert.Equal(t, w3w.ErrNoAPIKey, err)
+
+	_, err = w3w.NewKeyPool([]string{"a", " "}, w3w.KeyPoolOptions{})
+	assert.Equal(t, w3w.ErrNoAPIKey, err)
+
+	pool, err := w3w.NewKeyPool([]string{"a"}, w3w.KeyPoolOptions{})
+	assert.Nil(t, err)
+
+	_, err = w3w.New("", w3w.WithKeyPool(pool))
+	assert.Nil(t, err)
+}
+
+func TestKeyPoolSelection(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		selection  w3w.KeySelection
+		quarantine string
+
+		expectedKeys []string
+	}{
+		{
+			desc:      "given round robin, keys used in turn",
+			selection: w3w.RoundRobin,
+
+			expectedKeys: []string{"a", "b", "c", "a", "b", "c"},
+		},
+		{
+			desc:       "given round robin and a quarantined key, other keys used in turn",
+			selection:  w3w.RoundRobin,
+			quarantine: "b",
+
+			expectedKeys: []string{"a", "c", "a", "c", "a", "c"},
+		},
+		{
+			desc:      "given least used, keys spread evenly",
+			selection: w3w.LeastUsed,
+
+			expectedKeys: []string{"a", "b", "c", "a", "b", "c"},
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			pool, err := w3w.NewKeyPool([]string{"a", "b", "c"}, w3w.KeyPoolOptions{Selection: tt.selection})
+			assert.Nil(t, err)
+
+			if tt.quarantine != "" {
+				pool.Quarantine(tt.quarantine, "test")
+			}
+
+			var keys []string
+			for range tt.expectedKeys {
+				key, err := pool.Next()
+				assert.Nil(t, err)
+				keys = append(keys, key)
+			}
+
+			assert.Equal(t, tt.expectedKeys, keys)
+		})
+	}
+}
+
+func TestKeyPoolQuarantine(t *testing.T) {
+	s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+	defer s.Close()
+
+	pool, err := w3w.NewKeyPool([]string{"good", "spent"}, w3w.KeyPoolOptions{
+		QuarantineFor: 50 * time.Millisecond,
+	})
+	assert.Nil(t, err)
+
+	c, err := w3w.New("", w3w.WithKeyPool(pool))
+	assert.Nil(t, err)
+
+	get := func() error {
+		_, err := c.GetCoordinates(w3w.Words{"filled", "count", "soap"}, w3w.CoordinateOptions{APIURL: s.URL})
+		return err
+	}
+
+	// the second key is used and fails with QuotaExceeded
+	assert.Nil(t, get())
+	s.InjectError("", w3wtest.QuotaExceeded, 1)
+	assert.NotNil(t, get())
+
+	status := pool.Status()
+	assert.Equal(t, w3w.KeyID("spent"), status[1].KeyID)
+	assert.Equal(t, "QuotaExceeded", status[1].Reason)
+	assert.False(t, status[1].QuarantinedUntil.IsZero())
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, get())
+	}
+
+	pool.Quarantine("good", "InvalidKey")
+	assert.Equal(t, w3w.ErrNoKeyAvailable, get())
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Nil(t, get())
+
+	var keys []string
+	for _, u := range s.Requests() {
+		keys = append(keys, u.Query().Get("key"))
+	}
+	assert.Equal(t, []string{"good", "spent", "good", "good", "good", "spent"}, keys)
+}
+
+func TestKeyPoolInvalidInput(t *testing.T) {
+	s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+	defer s.Close()
+
+	pool, err := w3w.NewKeyPool([]string{"a", "b"}, w3w.KeyPoolOptions{})
+	assert.Nil(t, err)
+
+	c, err := w3w.New("", w3w.WithKeyPool(pool))
+	assert.Nil(t, err)
+
+	_, err = c.GetCoordinates(w3w.Words{"filled", "", "soap"}, w3w.CoordinateOptions{APIURL: s.URL})
+	assert.True(t, errors.Is(err, w3w.ErrEmptyWord))
+
+	_, err = c.GetWords(w3w.LatLng{Lat: 91}, w3w.WordOptions{APIURL: s.URL})
+	assert.True(t, errors.Is(err, w3w.ErrLatitudeOutOfRange))
+
+	// neither call picked a key from the pool
+	for _, st := range pool.Status() {
+		assert.Equal(t, int64(0), st.Uses)
+	}
+	assert.Len(t, s.Requests(), 0)
+}
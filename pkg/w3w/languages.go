@@ -0,0 +1,56 @@
+package w3w
+
+import (
+	"context"
+
+	"github.com/jonnypillar/what3words/internal/api"
+)
+
+const availableLanguagesRoute = "available-languages"
+
+// Language is a language 3 word addresses are available in
+type Language struct {
+	// Code is the ISO 639-1 code used as the Language option
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	NativeName string `json:"nativeName"`
+}
+
+// LanguageOptions ...
+type LanguageOptions struct {
+	APIURL string
+	// Key overrides the client's API key for the call
+	Key string
+}
+
+// availableLanguagesResponse defines the available-languages response body
+type availableLanguagesResponse struct {
+	Languages []Language `json:"languages"`
+}
+
+// AvailableLanguages returns the languages 3 word addresses are available in
+func (c Client) AvailableLanguages(opts LanguageOptions) ([]Language, error) {
+	return c.AvailableLanguagesContext(context.Background(), opts)
+}
+
+// AvailableLanguagesContext is AvailableLanguages with a context, which cancels the request when done
+func (c Client) AvailableLanguagesContext(ctx context.Context, opts LanguageOptions) ([]Language, error) {
+	key, err := c.selectKey(ctx, opts.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := api.NewURL(key, opts.APIURL, availableLanguagesRoute)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp availableLanguagesResponse
+
+	err = c.call(ctx, key, url.URL(), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Languages, nil
+}
@@ -0,0 +1,57 @@
+package w3w_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAvailableLanguages(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		apiError string
+
+		expectedLanguages []w3w.Language
+		expectedErr       error
+	}{
+		{
+			desc: "given the API returns languages, languages returned",
+
+			expectedLanguages: w3wtest.Languages,
+		},
+		{
+			desc:     "given the API returns an error, error returned",
+			apiError: w3wtest.InvalidKey,
+
+			expectedErr: w3w.Error{Code: w3wtest.InvalidKey, Message: "Authentication failed; invalid API key"},
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := w3wtest.NewServer()
+			defer s.Close()
+
+			if tt.apiError != "" {
+				s.InjectError(w3wtest.RouteAvailableLanguages, tt.apiError, 1)
+			}
+
+			c, err := w3w.New("foobar")
+			assert.Nil(t, err)
+
+			languages, err := c.AvailableLanguages(w3w.LanguageOptions{APIURL: s.URL})
+			assert.Len(t, s.Requests(), 1)
+
+			if tt.expectedErr != nil {
+				assert.True(t, errors.Is(err, tt.expectedErr), "expected %v, got %v", tt.expectedErr, err)
+				return
+			}
+			assert.Nil(t, err)
+
+			assert.Equal(t, tt.expectedLanguages, languages)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package w3w
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// Templates for Linkify, executed with each Verified address
+const (
+	// MarkdownTemplate links the address to its what3words map
+	MarkdownTemplate = "[{{.Text}}]({{.Result.Map}})"
+	// HTMLTemplate links the address to its what3words map, for use with
+	// LinkifyOptions.HTML
+	HTMLTemplate = `<a href="{{.Result.Map}}">{{.Text}}</a>`
+	// PlainTemplate adds the nearest place after the address
+	PlainTemplate = "{{.Text}} (near {{.Result.NearestPlace}})"
+)
+
+// LinkifyOptions configures Linkify
+type LinkifyOptions struct {
+	// Template rewrites each verified address, defaulting to MarkdownTemplate
+	Template string
+	// HTML escapes the text and executes Template as an html/template, which
+	// escapes the values it inserts, so the output is safe to embed in HTML
+	HTML bool
+	// Verify configures how the addresses are verified
+	Verify VerifyOptions
+}
+
+// executor is implemented by text/template and html/template templates
+type executor interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// Linkify rewrites the 3 word addresses in text with opts.Template, such as
+// to link them in support tickets or chat transcripts. The addresses are
+// found by FindPossible3wa and verified by VerifyPossible3wa, and text which
+// is not a verified address is left unchanged.
+func Linkify(ctx context.Context, conv CoordinatesConverter, text string, opts LinkifyOptions) (string, error) {
+	tmpl, err := parseLinkTemplate(opts)
+	if err != nil {
+		return "", err
+	}
+
+	escape := func(s string) string { return s }
+	if opts.HTML {
+		escape = html.EscapeString
+	}
+
+	verified, err := VerifyPossible3wa(ctx, conv, FindPossible3wa(text), opts.Verify)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		b    strings.Builder
+		link bytes.Buffer
+		end  int
+	)
+	for _, v := range verified {
+		link.Reset()
+		if err := tmpl.Execute(&link, v); err != nil {
+			return "", fmt.Errorf("error executing link template %w", err)
+		}
+
+		b.WriteString(escape(text[end:v.Start]))
+		b.Write(link.Bytes())
+		end = v.End
+	}
+	b.WriteString(escape(text[end:]))
+
+	return b.String(), nil
+}
+
+func parseLinkTemplate(opts LinkifyOptions) (executor, error) {
+	text := opts.Template
+	if text == "" {
+		text = MarkdownTemplate
+	}
+
+	var (
+		tmpl executor
+		err  error
+	)
+	if opts.HTML {
+		tmpl, err = htmltemplate.New("link").Parse(text)
+	} else {
+		tmpl, err = template.New("link").Parse(text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid link template %w", err)
+	}
+
+	return tmpl, nil
+}
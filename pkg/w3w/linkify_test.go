@@ -0,0 +1,88 @@
+package w3w_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkify(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		text     string
+		opts     w3w.LinkifyOptions
+		apiError string
+
+		expectedText string
+		expectedErr  bool
+	}{
+		{
+			desc: "given the default options, markdown links returned",
+			text: "I'm at ///filled.count.soap near the gate",
+
+			expectedText: "I'm at [///filled.count.soap](https://w3w.co/filled.count.soap) near the gate",
+		},
+		{
+			desc: "given the HTML template, text escaped and anchors returned",
+			text: "<b>filled.count.soap</b> & index.home.raft",
+			opts: w3w.LinkifyOptions{Template: w3w.HTMLTemplate, HTML: true},
+
+			expectedText: `&lt;b&gt;<a href="https://w3w.co/filled.count.soap">filled.count.soap</a>&lt;/b&gt; &amp; index.home.raft`,
+		},
+		{
+			desc: "given the plain template, nearest place added",
+			text: "Meet at filled.count.soap.",
+			opts: w3w.LinkifyOptions{Template: w3w.PlainTemplate},
+
+			expectedText: "Meet at filled.count.soap (near Bayswater, London).",
+		},
+		{
+			desc: "given no verified addresses, text returned unchanged",
+			text: "Meet at index.home.raft <today>",
+
+			expectedText: "Meet at index.home.raft <today>",
+		},
+		{
+			desc: "given an invalid template, error returned",
+			text: "filled.count.soap",
+			opts: w3w.LinkifyOptions{Template: "{{.Text"},
+
+			expectedErr: true,
+		},
+		{
+			desc:     "given the API returns an error, error returned",
+			text:     "filled.count.soap",
+			apiError: w3wtest.QuotaExceeded,
+
+			expectedErr: true,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+			defer s.Close()
+
+			if tt.apiError != "" {
+				s.InjectError("", tt.apiError, 0)
+			}
+
+			c, err := w3w.New("foobar")
+			assert.Nil(t, err)
+
+			tt.opts.Verify.Options.APIURL = s.URL
+			text, err := w3w.Linkify(context.Background(), c, tt.text, tt.opts)
+
+			if tt.expectedErr {
+				assert.NotNil(t, err)
+				assert.Empty(t, text)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectedText, text)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package w3w
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/jonnypillar/what3words/internal/api"
+)
+
+// LogLevel is the severity of a log record, matching the values of log/slog
+type LogLevel int
+
+// Log levels
+const (
+	LogLevelDebug LogLevel = -4
+	LogLevelInfo  LogLevel = 0
+	LogLevelWarn  LogLevel = 4
+	LogLevelError LogLevel = 8
+)
+
+// String returns the level's name
+func (l LogLevel) String() string {
+	switch {
+	case l < LogLevelInfo:
+		return "DEBUG"
+	case l < LogLevelWarn:
+		return "INFO"
+	case l < LogLevelError:
+		return "WARN"
+	default:
+		return "ERROR"
+	}
+}
+
+// Log record attribute keys
+const (
+	LogKeyRoute     = "route"
+	LogKeyURL       = "url"
+	LogKeyStatus    = "status"
+	LogKeyDuration  = "duration"
+	LogKeyErrorCode = "error_code"
+	LogKeyError     = "error"
+)
+
+// Logger receives the client's structured log records, args being
+// alternating keys and values in the style of log/slog. Adapters for
+// log/slog and the standard library's log package are in w3wlog.
+type Logger interface {
+	Log(ctx context.Context, level LogLevel, msg string, args ...interface{})
+}
+
+// LoggerFunc adapts a function to a Logger
+type LoggerFunc func(ctx context.Context, level LogLevel, msg string, args ...interface{})
+
+// Log implements Logger
+func (f LoggerFunc) Log(ctx context.Context, level LogLevel, msg string, args ...interface{}) {
+	f(ctx, level, msg, args...)
+}
+
+// WithLogger sets the logger for API requests. Each request is logged when
+// it starts, at debug level, and when it ends with its route, URL with the
+// API key redacted, HTTP status, duration and what3words error code. Ends
+// are logged at info level, warn for error responses and error when no
+// response was received. By default nothing is logged.
+func WithLogger(l Logger) Option {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// WithLogLevel sets the minimum level of records passed to the logger,
+// LogLevelInfo by default
+func WithLogLevel(level LogLevel) Option {
+	return func(c *Client) {
+		c.logLevel = level
+	}
+}
+
+// loggingTransport logs the requests it passes on
+type loggingTransport struct {
+	next   http.RoundTripper
+	logger Logger
+	level  LogLevel
+}
+
+func (t loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	route := api.Route(req.URL)
+	url := api.Redact(req.URL)
+
+	t.log(ctx, LogLevelDebug, "w3w request started",
+		LogKeyRoute, route,
+		LogKeyURL, url,
+	)
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.log(ctx, LogLevelError, "w3w request failed",
+			LogKeyRoute, route,
+			LogKeyURL, url,
+			LogKeyDuration, time.Since(start),
+			LogKeyError, err.Error(),
+		)
+
+		return nil, err
+	}
+
+	level := LogLevelInfo
+	args := []interface{}{
+		LogKeyRoute, route,
+		LogKeyURL, url,
+		LogKeyStatus, resp.StatusCode,
+		LogKeyDuration, time.Since(start),
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		level = LogLevelWarn
+
+		// only read error bodies when they will be logged. A failure to
+		// read one is logged, the response being passed on for the client
+		// to report the failure as it would without logging.
+		if t.enabled(level) {
+			code, err := api.ErrorCode(resp)
+			if err != nil {
+				args = append(args, LogKeyError, err.Error())
+			}
+			if code != "" {
+				args = append(args, LogKeyErrorCode, code)
+			}
+		}
+	}
+
+	t.log(ctx, level, "w3w request finished", args...)
+
+	return resp, nil
+}
+
+func (t loggingTransport) enabled(level LogLevel) bool {
+	return level >= t.level
+}
+
+func (t loggingTransport) log(ctx context.Context, level LogLevel, msg string, args ...interface{}) {
+	if t.enabled(level) {
+		t.logger.Log(ctx, level, msg, args...)
+	}
+}
+
+// withLogging returns a copy of hc, or the default client when nil, logging
+// its requests
+func withLogging(hc *http.Client, logger Logger, level LogLevel) *http.Client {
+	if hc == nil {
+		hc = api.DefaultClient()
+	}
+
+	next := hc.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	logged := *hc
+	logged.Transport = loggingTransport{
+		next:   next,
+		logger: logger,
+		level:  level,
+	}
+
+	return &logged
+}
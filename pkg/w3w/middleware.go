@@ -0,0 +1,203 @@
+package w3w
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/jonnypillar/what3words/internal/api"
+)
+
+// RedactedKey replaces the API key in a Request's parameters
+const RedactedKey = "REDACTED"
+
+// Request is an API call seen by middleware
+type Request struct {
+	// Route is the API route called, such as convert-to-3wa
+	Route string
+	// Params are the query parameters, the key being RedactedKey. Changes
+	// to them, other than the key, are sent to the API.
+	Params url.Values
+	// Header holds extra headers sent to the API
+	Header http.Header
+	// KeyID identifies the API key used, see KeyID
+	KeyID string
+
+	key string
+	url *url.URL
+}
+
+// Response is the outcome of an API call seen by middleware. When the API
+// was not reached StatusCode is 0 and Err describes why.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	// Body is the raw response body
+	Body []byte
+
+	// Result and Err are returned by the client's method. Result is only
+	// set for conversions, other calls decoding Body.
+	Result Result
+	Err    error
+}
+
+// Handler performs an API call
+type Handler func(ctx context.Context, req *Request) *Response
+
+// Middleware wraps a Handler, for example to change requests, observe
+// responses or answer calls without reaching the API
+type Middleware func(next Handler) Handler
+
+// WithMiddleware adds middleware around the client's API calls. The first
+// middleware added is the outermost, seeing requests first and responses
+// last.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+// WithOnRequest adds a hook called before each API call, in the order added
+// with other middleware. The hook may change the request, and returning an
+// error stops the call, the client's method returning the error.
+func WithOnRequest(hook func(ctx context.Context, req *Request) error) Option {
+	return WithMiddleware(func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) *Response {
+			if err := hook(ctx, req); err != nil {
+				return &Response{Err: err}
+			}
+
+			return next(ctx, req)
+		}
+	})
+}
+
+// WithOnResponse adds a hook called after each API call with its request
+// and response, in the order added with other middleware
+func WithOnResponse(hook func(ctx context.Context, req *Request, resp *Response)) Option {
+	return WithMiddleware(func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) *Response {
+			resp := next(ctx, req)
+			hook(ctx, req, resp)
+
+			return resp
+		}
+	})
+}
+
+// handler returns the client's middleware chain around send
+func (c Client) handler() Handler {
+	h := Handler(c.send)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+
+	return h
+}
+
+func (c Client) get(ctx context.Context, key, rawURL string) (Result, error) {
+	resp := c.do(ctx, key, rawURL)
+
+	return resp.Result, resp.Err
+}
+
+// call performs a call to a route other than the conversions, decoding the
+// response body into v
+func (c Client) call(ctx context.Context, key, rawURL string, v interface{}) error {
+	resp := c.do(ctx, key, rawURL)
+	if resp.Err != nil {
+		return resp.Err
+	}
+
+	err := json.Unmarshal(resp.Body, v)
+	if err != nil {
+		return fmt.Errorf("invalid JSON returned from API %w", err)
+	}
+
+	return nil
+}
+
+// do runs a call through the middleware chain, reporting its outcome to the
+// key pool
+func (c Client) do(ctx context.Context, key, rawURL string) *Response {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return &Response{Err: err}
+	}
+
+	params := u.Query()
+	params.Set(paramKey, RedactedKey)
+
+	resp := c.handler()(ctx, &Request{
+		Route:  api.Route(u),
+		Params: params,
+		Header: http.Header{},
+		KeyID:  KeyID(key),
+		key:    key,
+		url:    u,
+	})
+
+	if c.keyPool != nil {
+		c.keyPool.report(key, resp.Err)
+	}
+
+	return resp
+}
+
+// send is the innermost Handler, calling the API
+func (c Client) send(ctx context.Context, req *Request) *Response {
+	params := url.Values{}
+	for k, v := range req.Params {
+		params[k] = v
+	}
+	params.Set(paramKey, req.key)
+
+	u := *req.url
+	u.RawQuery = params.Encode()
+
+	raw, err := api.Fetch(ctx, c.httpClient, u.String(), req.Header)
+	if err != nil {
+		return &Response{Err: err}
+	}
+
+	resp := &Response{
+		StatusCode: raw.StatusCode,
+		Header:     raw.Header,
+		Body:       raw.Body,
+	}
+
+	var (
+		decoded api.Response
+		body    json.RawMessage
+		target  interface{} = &body
+	)
+	if isConversion(req.Route) {
+		target = &decoded
+	}
+
+	err = api.DecodeInto(raw, target)
+	if err != nil {
+		var apiErr api.ErrorResponse
+
+		if errors.As(err, &apiErr) {
+			resp.Err = newResponseError(apiErr)
+		} else {
+			resp.Err = err
+		}
+
+		return resp
+	}
+
+	if isConversion(req.Route) {
+		resp.Result = newResponse(&decoded)
+	}
+
+	return resp
+}
+
+func isConversion(route string) bool {
+	return route == convertToWordsRoute || route == convertToCoordinatesRoute
+}
@@ -0,0 +1,147 @@
+package w3w_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	errDenied := errors.New("denied")
+	cached := w3w.Result{Words: "cached.three.words"}
+
+	testCases := []struct {
+		desc  string
+		opts  func(seen *[]string) []w3w.Option
+		words w3w.Words
+
+		expectedResult   w3w.Result
+		expectedErr      error
+		expectedSeen     []string
+		expectedRequests int
+	}{
+		{
+			desc: "given hooks, request and response seen with the key redacted",
+			opts: func(seen *[]string) []w3w.Option {
+				return []w3w.Option{
+					w3w.WithOnRequest(func(_ context.Context, req *w3w.Request) error {
+						*seen = append(*seen, "request "+req.Route+" "+req.Params.Encode())
+						return nil
+					}),
+					w3w.WithOnResponse(func(_ context.Context, req *w3w.Request, resp *w3w.Response) {
+						*seen = append(*seen, "response "+resp.Result.Words+" "+http.StatusText(resp.StatusCode))
+					}),
+				}
+			},
+			words: w3w.Words{"filled", "count", "soap"},
+
+			expectedResult: w3wtest.FilledCountSoap,
+			expectedSeen: []string{
+				"request convert-to-coordinates format=json&key=REDACTED&words=filled.count.soap",
+				"response filled.count.soap OK",
+			},
+			expectedRequests: 1,
+		},
+		{
+			desc: "given an API error, raw body and decoded error seen",
+			opts: func(seen *[]string) []w3w.Option {
+				return []w3w.Option{
+					w3w.WithOnResponse(func(_ context.Context, req *w3w.Request, resp *w3w.Response) {
+						*seen = append(*seen, string(resp.Body), resp.Err.Error())
+					}),
+				}
+			},
+			words: w3w.Words{"one", "two", "three"},
+
+			expectedErr: w3w.Error{Code: "BadWords", Message: "Invalid or non-existent 3 word address"},
+			expectedSeen: []string{
+				`{"error":{"code":"BadWords","message":"Invalid or non-existent 3 word address"}}` + "\n",
+				"BadWords: Invalid or non-existent 3 word address",
+			},
+			expectedRequests: 1,
+		},
+		{
+			desc: "given an OnRequest hook returning an error, error returned without calling the API",
+			opts: func(seen *[]string) []w3w.Option {
+				return []w3w.Option{
+					w3w.WithOnRequest(func(context.Context, *w3w.Request) error {
+						return errDenied
+					}),
+				}
+			},
+			words: w3w.Words{"filled", "count", "soap"},
+
+			expectedErr: errDenied,
+		},
+		{
+			desc: "given middleware answering the call, its result returned without calling the API",
+			opts: func(seen *[]string) []w3w.Option {
+				return []w3w.Option{
+					w3w.WithOnResponse(func(_ context.Context, req *w3w.Request, resp *w3w.Response) {
+						*seen = append(*seen, "outer "+resp.Result.Words)
+					}),
+					w3w.WithMiddleware(func(next w3w.Handler) w3w.Handler {
+						return func(ctx context.Context, req *w3w.Request) *w3w.Response {
+							return &w3w.Response{Result: cached}
+						}
+					}),
+				}
+			},
+			words: w3w.Words{"filled", "count", "soap"},
+
+			expectedResult: cached,
+			expectedSeen:   []string{"outer cached.three.words"},
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+			defer s.Close()
+
+			var seen []string
+			c, err := w3w.New("foobar", tt.opts(&seen)...)
+			assert.Nil(t, err)
+
+			res, err := c.GetCoordinates(tt.words, w3w.CoordinateOptions{APIURL: s.URL})
+
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectedResult, res)
+			}
+			assert.Equal(t, tt.expectedSeen, seen)
+			assert.Len(t, s.Requests(), tt.expectedRequests)
+		})
+	}
+}
+
+func TestMiddlewareChangesRequest(t *testing.T) {
+	var tenant string
+	s := testServer(func(w http.ResponseWriter, r *http.Request) {
+		tenant = r.Header.Get("X-Tenant")
+		assert.Equal(t, "foobar", r.URL.Query().Get("key"))
+		assert.Equal(t, "fr", r.URL.Query().Get("language"))
+
+		w.Write([]byte(`{"words":"conduite.richissime.empâter"}`))
+	})
+	defer s.Close()
+
+	c, err := w3w.New("foobar", w3w.WithOnRequest(func(_ context.Context, req *w3w.Request) error {
+		req.Header.Set("X-Tenant", "billing")
+		req.Params.Set("language", "fr")
+		return nil
+	}))
+	assert.Nil(t, err)
+
+	res, err := c.GetWords(w3w.Coordinates{Lat: 51.520847, Lng: -0.195521}, w3w.WordOptions{APIURL: s.URL})
+	assert.Nil(t, err)
+	assert.Equal(t, "conduite.richissime.empâter", res.Words)
+	assert.Equal(t, "billing", tenant)
+}
@@ -0,0 +1,316 @@
+package w3w
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+)
+
+const (
+	defaultMaxSquares = 100
+
+	// gridEpsilon is the difference below which two grid lines are taken to
+	// be the same line
+	gridEpsilon = 1e-9
+)
+
+var (
+	// ErrTooManySquares is returned by Neighbours for a radius covering more
+	// squares than NeighbourOptions.MaxSquares
+	ErrTooManySquares = errors.New("radius covers too many squares")
+	// ErrIncompleteGrid is returned by Neighbours when the grid section
+	// around the address does not enclose its square
+	ErrIncompleteGrid = errors.New("grid section does not enclose the square")
+)
+
+// NeighbourOptions ...
+type NeighbourOptions struct {
+	APIURL   string
+	Language string
+	// Key overrides the client's API key for the calls
+	Key string
+	// Workers sets the number of squares resolved concurrently, defaulting
+	// to 4
+	Workers int
+	// MaxSquares limits the squares a radius may cover, as each costs an
+	// API call, defaulting to 100
+	MaxSquares int
+}
+
+// Neighbour is a square around a 3 word address
+type Neighbour struct {
+	// Ring is 1 for the 8 adjacent squares, 2 for the 16 around those and
+	// so on
+	Ring   int
+	Result Result
+}
+
+// cell is the centre of a neighbouring square
+type cell struct {
+	ring   int
+	centre LatLng
+}
+
+// Neighbours returns the squares around a 3 word address. A radius of 0
+// returns the 8 adjacent squares, otherwise every square whose centre is
+// within radius metres of the address's centre is returned. The squares are
+// read from the grid section around the address and are ordered by ring
+// outwards from the address and clockwise from north within a ring.
+func (c Client) Neighbours(words Words, radius float64, opts NeighbourOptions) ([]Neighbour, error) {
+	return c.NeighboursContext(context.Background(), words, radius, opts)
+}
+
+// NeighboursContext is Neighbours with a context, which cancels the requests when done
+func (c Client) NeighboursContext(ctx context.Context, words Words, radius float64, opts NeighbourOptions) ([]Neighbour, error) {
+	if opts.Workers < 1 {
+		opts.Workers = defaultWorkers
+	}
+	if opts.MaxSquares < 1 {
+		opts.MaxSquares = defaultMaxSquares
+	}
+
+	origin, err := c.GetCoordinatesContext(ctx, words, CoordinateOptions{
+		APIURL: opts.APIURL,
+		Key:    opts.Key,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sq := origin.Square
+	size := math.Max(sq.WidthMeters(), sq.HeightMeters())
+
+	// every square within the circle a square's diagonal inside the radius
+	// has its centre within the radius, so the radius is rejected before
+	// asking for a section the API may refuse as too big
+	inner := math.Max(radius-size*math.Sqrt2, 0)
+	if math.Pi*inner*inner/sq.AreaM2() > float64(opts.MaxSquares) {
+		return nil, ErrTooManySquares
+	}
+
+	// the section reaches a square past the furthest centre so the squares
+	// at its edge are whole
+	section := sq.Expand(math.Max(radius, size) + size)
+	lines, err := c.GridSectionContext(ctx, section, GridSectionOptions{
+		APIURL: opts.APIURL,
+		Key:    opts.Key,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cells, err := neighbourCells(sq, section, lines, radius, opts.MaxSquares)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, len(cells))
+	err = forEach(ctx, len(cells), opts.Workers, func(ctx context.Context, i int) error {
+		res, err := c.GetWordsContext(ctx, cells[i].centre, WordOptions{
+			APIURL:   opts.APIURL,
+			Language: opts.Language,
+			Key:      opts.Key,
+		})
+		results[i] = res
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	neighbours := make([]Neighbour, len(results))
+	for i, res := range results {
+		neighbours[i] = Neighbour{Ring: cells[i].ring, Result: res}
+	}
+
+	return neighbours, nil
+}
+
+// neighbourCells returns the centres of the squares around sq in ring order,
+// as bounded by the lines of the grid section. Squares are not aligned
+// between rows, so a square's offset east is counted from the square of its
+// row containing sq's centre.
+func neighbourCells(sq, section Square, lines []Line, radius float64, max int) ([]cell, error) {
+	centre := sq.Center()
+	west := section.Southwest.Lng
+	rows := gridRows(lines)
+
+	origin := -1
+	for i, r := range rows {
+		if centre.Lat > r.south && centre.Lat < r.north {
+			origin = i
+			break
+		}
+	}
+	// there must be a row either side of the square, unless it is at a pole
+	switch {
+	case origin < 0,
+		origin == 0 && rows[0].south > -90,
+		origin == len(rows)-1 && rows[origin].north < 90:
+		return nil, ErrIncompleteGrid
+	}
+
+	// the squares of each ring keyed by their north and east offsets
+	rings := map[int]map[[2]int]LatLng{}
+	for i, r := range rows {
+		lat := (r.south + r.north) / 2
+		edges := r.edges(lines, west)
+
+		// the square of the row containing the centre, or -1 when the row
+		// starts east of it
+		col := -1
+		for j := 0; j < len(edges)-1; j++ {
+			if eastOf(edges[j], west) <= eastOf(centre.Lng, west) {
+				col = j
+			}
+		}
+		if i == origin && (col < 1 || col > len(edges)-3) {
+			return nil, ErrIncompleteGrid
+		}
+
+		for j := 0; j < len(edges)-1; j++ {
+			off := [2]int{i - origin, j - col}
+			if off == [2]int{} {
+				continue
+			}
+
+			span := eastOf(edges[j+1], edges[j])
+			p := LatLng{Lat: lat, Lng: edges[j] + span/2}.Normalise()
+
+			ring := maxAbs(off[0], off[1])
+			if radius == 0 && ring > 1 || radius > 0 && centre.DistanceTo(p) > radius {
+				continue
+			}
+
+			if rings[ring] == nil {
+				rings[ring] = map[[2]int]LatLng{}
+			}
+			rings[ring][off] = p
+		}
+	}
+
+	var cells []cell
+	for ring := 1; len(rings[ring]) > 0; ring++ {
+		for _, off := range ringOffsets(ring) {
+			p, ok := rings[ring][off]
+			if !ok {
+				continue
+			}
+
+			if len(cells) == max {
+				return nil, ErrTooManySquares
+			}
+			cells = append(cells, cell{ring: ring, centre: p})
+		}
+	}
+
+	return cells, nil
+}
+
+// gridRow is a row of the grid between two lines of latitude
+type gridRow struct {
+	south, north float64
+}
+
+// gridRows returns the rows bounded by the lines of latitude, south to north
+func gridRows(lines []Line) []gridRow {
+	var lats []float64
+	for _, l := range lines {
+		if l.Start.Lat == l.End.Lat {
+			lats = append(lats, l.Start.Lat)
+		}
+	}
+	lats = uniqueSorted(lats, func(f float64) float64 { return f })
+
+	rows := make([]gridRow, 0, len(lats))
+	for i := 1; i < len(lats); i++ {
+		rows = append(rows, gridRow{south: lats[i-1], north: lats[i]})
+	}
+
+	return rows
+}
+
+// edges returns the longitudes of the lines crossing the middle of the row,
+// ordered eastwards from west so rows crossing the antimeridian are handled
+func (r gridRow) edges(lines []Line, west float64) []float64 {
+	lat := (r.south + r.north) / 2
+
+	var lngs []float64
+	for _, l := range lines {
+		if l.Start.Lng != l.End.Lng {
+			continue
+		}
+
+		if lat > math.Min(l.Start.Lat, l.End.Lat) && lat < math.Max(l.Start.Lat, l.End.Lat) {
+			lngs = append(lngs, l.Start.Lng)
+		}
+	}
+
+	return uniqueSorted(lngs, func(lng float64) float64 { return eastOf(lng, west) })
+}
+
+// uniqueSorted sorts fs by key, dropping those within gridEpsilon of the one
+// before
+func uniqueSorted(fs []float64, key func(float64) float64) []float64 {
+	sort.Slice(fs, func(i, j int) bool { return key(fs[i]) < key(fs[j]) })
+
+	var unique []float64
+	for i, f := range fs {
+		if i > 0 && key(f)-key(fs[i-1]) < gridEpsilon {
+			continue
+		}
+		unique = append(unique, f)
+	}
+
+	return unique
+}
+
+// eastOf returns the degrees of longitude lng lies east of west
+func eastOf(lng, west float64) float64 {
+	east := lng - west
+	if east < 0 {
+		east += 360
+	}
+
+	return east
+}
+
+func maxAbs(a, b int) int {
+	if a < 0 {
+		a = -a
+	}
+	if b < 0 {
+		b = -b
+	}
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// ringOffsets returns the north and east offsets, in squares, of the 8*ring
+// squares in a ring, clockwise from north
+func ringOffsets(ring int) [][2]int {
+	offsets := make([][2]int, 0, 8*ring)
+
+	for east := 0; east <= ring; east++ {
+		offsets = append(offsets, [2]int{ring, east})
+	}
+	for north := ring - 1; north >= -ring; north-- {
+		offsets = append(offsets, [2]int{north, ring})
+	}
+	for east := ring - 1; east >= -ring; east-- {
+		offsets = append(offsets, [2]int{-ring, east})
+	}
+	for north := -ring + 1; north <= ring; north++ {
+		offsets = append(offsets, [2]int{north, -ring})
+	}
+	for east := -ring + 1; east < 0; east++ {
+		offsets = append(offsets, [2]int{ring, east})
+	}
+
+	return offsets
+}
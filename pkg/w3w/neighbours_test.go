@@ -0,0 +1,139 @@
+package w3w_test
+
+import (
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNeighbours(t *testing.T) {
+	const seed = 42
+
+	origin := w3wtest.NewGrid(seed).Result(51.520847, -0.195521)
+	words, err := w3w.ParseWords(origin.Words)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		desc       string
+		radius     float64
+		maxSquares int
+		apiError   string
+		noGrid     bool
+
+		expectedCount    int
+		expectedRings    int
+		expectedRequests int
+		expectedErr      error
+	}{
+		{
+			desc: "given no radius, the 8 adjacent squares returned",
+
+			expectedCount:    8,
+			expectedRings:    1,
+			expectedRequests: 10,
+		},
+		{
+			desc:   "given a radius, the squares within it returned",
+			radius: 10,
+
+			expectedCount:    34,
+			expectedRings:    3,
+			expectedRequests: 36,
+		},
+		{
+			desc:       "given a radius covering too many squares, ErrTooManySquares returned",
+			radius:     1000,
+			maxSquares: 50,
+
+			expectedRequests: 1,
+			expectedErr:      w3w.ErrTooManySquares,
+		},
+		{
+			desc:   "given a grid section with only the address's square, ErrIncompleteGrid returned",
+			noGrid: true,
+
+			expectedRequests: 2,
+			expectedErr:      w3w.ErrIncompleteGrid,
+		},
+		{
+			desc:     "given the API returns an error, error returned",
+			apiError: w3wtest.QuotaExceeded,
+
+			expectedRequests: 1,
+			expectedErr:      w3w.Error{Code: w3wtest.QuotaExceeded, Message: "Quota Exceeded. Please upgrade your usage plan, or contact support@what3words.com"},
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			var s *w3wtest.Server
+			if tt.noGrid {
+				s = w3wtest.NewServer(origin)
+			} else {
+				s = w3wtest.NewGridServer(seed)
+			}
+			defer s.Close()
+
+			if tt.apiError != "" {
+				s.InjectError("", tt.apiError, 0)
+			}
+
+			c, err := w3w.New("foobar")
+			assert.Nil(t, err)
+
+			neighbours, err := c.Neighbours(words, tt.radius, w3w.NeighbourOptions{
+				APIURL:     s.URL,
+				MaxSquares: tt.maxSquares,
+			})
+
+			assert.Len(t, s.Requests(), tt.expectedRequests)
+
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				assert.Nil(t, neighbours)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Len(t, neighbours, tt.expectedCount)
+
+			seen := map[string]bool{origin.Words: true}
+			ring, bearing := 1, -90.0
+			for _, n := range neighbours {
+				assert.False(t, seen[n.Result.Words], n.Result.Words)
+				seen[n.Result.Words] = true
+
+				// ordered by ring, then clockwise from north
+				if n.Ring > ring {
+					ring, bearing = n.Ring, -90
+				}
+				b := origin.BearingTo(n.Result)
+				if bearing < 0 && b > 270 {
+					// the first square of a ring may be just west of north
+					b -= 360
+				}
+				assert.Equal(t, ring, n.Ring)
+				assert.True(t, b > bearing, "%v after %v", b, bearing)
+				bearing = b
+
+				if tt.radius > 0 {
+					assert.True(t, origin.DistanceTo(n.Result) <= tt.radius)
+				}
+			}
+			assert.Equal(t, tt.expectedRings, ring)
+
+			// every square sharing an edge or corner with the address's
+			// square is a neighbour
+			grid := w3wtest.NewGrid(seed)
+			for _, p := range origin.Square.Expand(0.1).Polygon() {
+				assert.True(t, seen[grid.Result(p.Lat, p.Lng).Words], p)
+			}
+			for _, bearing := range []float64{0, 90, 180, 270} {
+				p := origin.Coordinates.Destination(bearing, 2)
+				assert.True(t, seen[grid.Result(p.Lat, p.Lng).Words], p)
+			}
+		})
+	}
+}
@@ -1,14 +1,36 @@
 package w3w
 
+import "net/http"
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used to call the W3W APIs, for example
+// to use a custom transport. By default a client with a 30 second timeout is used.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
 // WordOptions ...
 type WordOptions struct {
 	APIURL   string
 	Language string
 	Format   string
+	// Key overrides the client's API key for the call
+	Key string
+	// ClampLatitude clamps the latitude to [-90, 90] instead of rejecting it
+	ClampLatitude bool
+	// NormaliseLongitude wraps the longitude into [-180, 180] instead of
+	// rejecting it
+	NormaliseLongitude bool
 }
 
 // CoordinateOptions ...
 type CoordinateOptions struct {
 	APIURL string
 	Format string
+	// Key overrides the client's API key for the call
+	Key string
 }
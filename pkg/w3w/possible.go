@@ -0,0 +1,68 @@
+package w3w
+
+import (
+	"regexp"
+	"strings"
+)
+
+// wordsSeparators are the characters separating the words of an address.
+// Some languages write the "." as one of the others.
+const wordsSeparators = ".｡。･・︒។։။۔።।"
+
+// The pattern used by what3words to detect possible 3 word addresses in any
+// language. A word is a run of characters other than digits, whitespace,
+// punctuation and separators; Vietnamese words may be up to 4 space
+// separated syllables.
+const (
+	wordPattern      = `[^0-9` + "`" + `~!@#$%^&*()+\-_=\[{\]}\\|'<,.>?/";:£§º©®\s` + wordsSeparators + `]+`
+	separatorPattern = `[` + wordsSeparators + `]`
+	syllablesPattern = wordPattern + `(?:[\x{0020}\x{00A0}]` + wordPattern + `){1,3}`
+
+	wordsPattern = `(?:` +
+		wordPattern + separatorPattern + wordPattern + separatorPattern + wordPattern + `|` +
+		syllablesPattern + separatorPattern + syllablesPattern + separatorPattern + syllablesPattern +
+		`)`
+)
+
+var (
+	possible3waRegexp = regexp.MustCompile(`^/*` + wordsPattern + `$`)
+	wordsRegexp       = regexp.MustCompile(`^` + wordsPattern + `$`)
+)
+
+// separatorReplacer replaces the alternative separators with wordsDelimiter
+var separatorReplacer = newSeparatorReplacer()
+
+func newSeparatorReplacer() *strings.Replacer {
+	var oldnew []string
+	for _, r := range wordsSeparators {
+		if s := string(r); s != wordsDelimiter {
+			oldnew = append(oldnew, s, wordsDelimiter)
+		}
+	}
+
+	return strings.NewReplacer(oldnew...)
+}
+
+// IsPossible3wa reports whether s has the form of a 3 word address in any
+// language, such as filled.count.soap, ///filled.count.soap or
+// index｡home｡raft written with an alternative separator. It does not call
+// the API, so a possible address may still not exist.
+func IsPossible3wa(s string) bool {
+	return possible3waRegexp.MatchString(s)
+}
+
+// ValidateWords returns ErrEmptyWord if a word is empty or ErrInvalidWord if
+// the words do not form a possible 3 word address
+func ValidateWords(w Words) error {
+	for _, word := range w {
+		if strings.TrimSpace(word) == "" {
+			return ErrEmptyWord
+		}
+	}
+
+	if !wordsRegexp.MatchString(w.String()) {
+		return ErrInvalidWord
+	}
+
+	return nil
+}
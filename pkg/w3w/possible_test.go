@@ -0,0 +1,143 @@
+package w3w_test
+
+import (
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPossible3wa(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		input string
+
+		expected bool
+	}{
+		{
+			desc:     "given a dotted address, true returned",
+			input:    "filled.count.soap",
+			expected: true,
+		},
+		{
+			desc:     "given a prefixed address, true returned",
+			input:    "///filled.count.soap",
+			expected: true,
+		},
+		{
+			desc:     "given an address with accents, true returned",
+			input:    "conduite.richissime.empâter",
+			expected: true,
+		},
+		{
+			desc:     "given a Cyrillic address, true returned",
+			input:    "вещи.ладони.подросток",
+			expected: true,
+		},
+		{
+			desc:     "given a Japanese address with ideographic full stops, true returned",
+			input:    "こくさい。ていか。かざす",
+			expected: true,
+		},
+		{
+			desc:     "given an address with halfwidth ideographic full stops, true returned",
+			input:    "index｡home｡raft",
+			expected: true,
+		},
+		{
+			desc:     "given a Vietnamese address of several syllables, true returned",
+			input:    "nước hoa.nhà hàng.con mèo",
+			expected: true,
+		},
+		{
+			desc:     "given two words, false returned",
+			input:    "foo.bar",
+			expected: false,
+		},
+		{
+			desc:     "given four words, false returned",
+			input:    "filled.count.soap.extra",
+			expected: false,
+		},
+		{
+			desc:     "given a word containing digits, false returned",
+			input:    "filled.count.s0ap",
+			expected: false,
+		},
+		{
+			desc:     "given a word containing punctuation, false returned",
+			input:    "filled.count.so-ap",
+			expected: false,
+		},
+		{
+			desc:     "given an empty word, false returned",
+			input:    "filled..soap",
+			expected: false,
+		},
+		{
+			desc:     "given surrounding whitespace, false returned",
+			input:    " filled.count.soap",
+			expected: false,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, w3w.IsPossible3wa(tt.input))
+		})
+	}
+}
+
+func TestValidateWords(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		words w3w.Words
+
+		expectedErr error
+	}{
+		{
+			desc:  "given valid words, nil returned",
+			words: w3w.Words{"filled", "count", "soap"},
+		},
+		{
+			desc:  "given multilingual words, nil returned",
+			words: w3w.Words{"こくさい", "ていか", "かざす"},
+		},
+		{
+			desc:  "given a whitespace word, ErrEmptyWord returned",
+			words: w3w.Words{"filled", " ", "soap"},
+
+			expectedErr: w3w.ErrEmptyWord,
+		},
+		{
+			desc:  "given a word containing a separator, ErrInvalidWord returned",
+			words: w3w.Words{"filled", "count.soap", "extra"},
+
+			expectedErr: w3w.ErrInvalidWord,
+		},
+		{
+			desc:  "given a word containing an alternative separator, ErrInvalidWord returned",
+			words: w3w.Words{"こくさい", "ていか。かざす", "extra"},
+
+			expectedErr: w3w.ErrInvalidWord,
+		},
+		{
+			desc:  "given a word prefixed with a slash, ErrInvalidWord returned",
+			words: w3w.Words{"/filled", "count", "soap"},
+
+			expectedErr: w3w.ErrInvalidWord,
+		},
+		{
+			desc:  "given a word containing digits, ErrInvalidWord returned",
+			words: w3w.Words{"filled", "count", "s0ap"},
+
+			expectedErr: w3w.ErrInvalidWord,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expectedErr, w3w.ValidateWords(tt.words))
+		})
+	}
+}
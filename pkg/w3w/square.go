@@ -0,0 +1,114 @@
+package w3w
+
+import "math"
+
+// earthRadiusMeters is the mean radius of the Earth
+const earthRadiusMeters = 6371008.8
+
+// Center returns the centre of the square
+func (s Square) Center() LatLng {
+	return LatLng{
+		Lat: (s.Southwest.Lat + s.Northeast.Lat) / 2,
+		Lng: LatLng{Lng: s.Southwest.Lng + s.lngSpan()/2}.Normalise().Lng,
+	}
+}
+
+// Contains reports whether p is within the square, including its edges
+func (s Square) Contains(p LatLng) bool {
+	if p.Lat < s.Southwest.Lat || p.Lat > s.Northeast.Lat {
+		return false
+	}
+
+	// measured eastwards from the west edge, so squares crossing the
+	// antimeridian are handled
+	east := p.Lng - s.Southwest.Lng
+	if east < 0 {
+		east += 360
+	}
+
+	return east <= s.lngSpan()
+}
+
+// WidthMeters returns the east west length of the square at its centre
+func (s Square) WidthMeters() float64 {
+	return earthRadiusMeters * radians(s.lngSpan()) * math.Cos(radians(s.Center().Lat))
+}
+
+// HeightMeters returns the north south length of the square
+func (s Square) HeightMeters() float64 {
+	return earthRadiusMeters * radians(s.Northeast.Lat-s.Southwest.Lat)
+}
+
+// AreaM2 returns the area of the square in square metres, treating the
+// Earth as a sphere
+func (s Square) AreaM2() float64 {
+	return earthRadiusMeters * earthRadiusMeters * radians(s.lngSpan()) *
+		math.Abs(math.Sin(radians(s.Northeast.Lat))-math.Sin(radians(s.Southwest.Lat)))
+}
+
+// Polygon returns the corners of the square anticlockwise from the south
+// west, repeating the first corner to close the ring as GeoJSON does
+func (s Square) Polygon() []LatLng {
+	sw, ne := s.Southwest, s.Northeast
+
+	return []LatLng{
+		sw,
+		{Lat: sw.Lat, Lng: ne.Lng},
+		ne,
+		{Lat: ne.Lat, Lng: sw.Lng},
+		sw,
+	}
+}
+
+// Expand returns the square grown by meters on every side, or shrunk when
+// meters is negative. Latitudes are clamped at the poles, a square grown
+// all the way round the Earth spans every longitude, and one shrunk past
+// half its size collapses onto its centre.
+func (s Square) Expand(meters float64) Square {
+	center := s.Center()
+
+	dLat := degrees(meters / earthRadiusMeters)
+	// a metre of longitude grows without bound towards the poles, so the
+	// change is capped at half way round
+	dLng := degrees(meters / (earthRadiusMeters * math.Cos(radians(center.Lat))))
+	dLng = math.Max(-180, math.Min(180, dLng))
+
+	sw := LatLng{Lat: s.Southwest.Lat - dLat, Lng: s.Southwest.Lng - dLng}
+	ne := LatLng{Lat: s.Northeast.Lat + dLat, Lng: s.Northeast.Lng + dLng}
+
+	if sw.Lat > ne.Lat {
+		sw.Lat, ne.Lat = center.Lat, center.Lat
+	}
+
+	span := s.lngSpan() + 2*dLng
+	switch {
+	case span < 0:
+		sw.Lng, ne.Lng = center.Lng, center.Lng
+	case span >= 360:
+		sw.Lng, ne.Lng = -180, 180
+	}
+
+	return Square{
+		Southwest: sw.Clamp().Normalise(),
+		Northeast: ne.Clamp().Normalise(),
+	}
+}
+
+// lngSpan returns the degrees of longitude the square spans eastwards from
+// its west edge
+func (s Square) lngSpan() float64 {
+	span := s.Northeast.Lng - s.Southwest.Lng
+	if span < 0 {
+		span += 360
+	}
+
+	return span
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
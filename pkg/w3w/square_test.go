@@ -0,0 +1,194 @@
+package w3w_test
+
+import (
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/stretchr/testify/assert"
+)
+
+// filledCountSoap is the square of ///filled.count.soap
+var filledCountSoap = w3w.Square{
+	Southwest: w3w.LatLng{Lat: 51.520833, Lng: -0.195543},
+	Northeast: w3w.LatLng{Lat: 51.52086, Lng: -0.195499},
+}
+
+// antimeridian is a square crossing the antimeridian
+var antimeridian = w3w.Square{
+	Southwest: w3w.LatLng{Lat: -16.5, Lng: 179.5},
+	Northeast: w3w.LatLng{Lat: -15.5, Lng: -179.5},
+}
+
+func TestSquareCenter(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		square w3w.Square
+
+		expectedCenter w3w.LatLng
+	}{
+		{
+			desc:   "given a square, its centre returned",
+			square: filledCountSoap,
+
+			expectedCenter: w3w.LatLng{Lat: 51.5208465, Lng: -0.195521},
+		},
+		{
+			desc:   "given a square crossing the antimeridian, its centre returned",
+			square: antimeridian,
+
+			expectedCenter: w3w.LatLng{Lat: -16, Lng: 180},
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			center := tt.square.Center()
+
+			assert.InDelta(t, tt.expectedCenter.Lat, center.Lat, 1e-9)
+			assert.InDelta(t, tt.expectedCenter.Lng, center.Lng, 1e-9)
+		})
+	}
+}
+
+func TestSquareContains(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		square w3w.Square
+		point  w3w.LatLng
+
+		expected bool
+	}{
+		{
+			desc:     "given a point within the square, true returned",
+			square:   filledCountSoap,
+			point:    w3w.LatLng{Lat: 51.520847, Lng: -0.195521},
+			expected: true,
+		},
+		{
+			desc:     "given a corner of the square, true returned",
+			square:   filledCountSoap,
+			point:    filledCountSoap.Northeast,
+			expected: true,
+		},
+		{
+			desc:     "given a point north of the square, false returned",
+			square:   filledCountSoap,
+			point:    w3w.LatLng{Lat: 51.520861, Lng: -0.195521},
+			expected: false,
+		},
+		{
+			desc:     "given a point east of the square, false returned",
+			square:   filledCountSoap,
+			point:    w3w.LatLng{Lat: 51.520847, Lng: -0.195498},
+			expected: false,
+		},
+		{
+			desc:     "given a point across the antimeridian within the square, true returned",
+			square:   antimeridian,
+			point:    w3w.LatLng{Lat: -16, Lng: -179.8},
+			expected: true,
+		},
+		{
+			desc:     "given a point outside a square crossing the antimeridian, false returned",
+			square:   antimeridian,
+			point:    w3w.LatLng{Lat: -16, Lng: 0},
+			expected: false,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.square.Contains(tt.point))
+		})
+	}
+}
+
+func TestSquareDimensions(t *testing.T) {
+	sq := filledCountSoap
+
+	// what3words squares are about 3 metres across
+	assert.InDelta(t, 3.04, sq.WidthMeters(), 0.01)
+	assert.InDelta(t, 3.00, sq.HeightMeters(), 0.01)
+	assert.InDelta(t, sq.WidthMeters()*sq.HeightMeters(), sq.AreaM2(), 0.01)
+}
+
+func TestSquarePolygon(t *testing.T) {
+	assert.Equal(t, []w3w.LatLng{
+		{Lat: 51.520833, Lng: -0.195543},
+		{Lat: 51.520833, Lng: -0.195499},
+		{Lat: 51.52086, Lng: -0.195499},
+		{Lat: 51.52086, Lng: -0.195543},
+		{Lat: 51.520833, Lng: -0.195543},
+	}, filledCountSoap.Polygon())
+}
+
+func TestSquareExpand(t *testing.T) {
+	expanded := filledCountSoap.Expand(1)
+
+	assert.InDelta(t, filledCountSoap.WidthMeters()+2, expanded.WidthMeters(), 0.001)
+	assert.InDelta(t, filledCountSoap.HeightMeters()+2, expanded.HeightMeters(), 0.001)
+	assert.InDelta(t, filledCountSoap.Center().Lat, expanded.Center().Lat, 1e-9)
+
+	polar := w3w.Square{
+		Southwest: w3w.LatLng{Lat: 89.99999, Lng: 0},
+		Northeast: w3w.LatLng{Lat: 90, Lng: 0.00001},
+	}
+	assert.Equal(t, float64(90), polar.Expand(10).Northeast.Lat)
+}
+
+func TestSquareExpandLimits(t *testing.T) {
+	polar := w3w.Square{
+		Southwest: w3w.LatLng{Lat: 89.99999, Lng: 0},
+		Northeast: w3w.LatLng{Lat: 90, Lng: 0.00001},
+	}
+
+	testCases := []struct {
+		desc   string
+		square w3w.Square
+		meters float64
+
+		expected w3w.Square
+	}{
+		{
+			desc:   "given a square shrunk past half its size, its centre returned",
+			square: filledCountSoap,
+			meters: -10,
+
+			expected: w3w.Square{
+				Southwest: filledCountSoap.Center(),
+				Northeast: filledCountSoap.Center(),
+			},
+		},
+		{
+			desc:   "given a square at the pole grown, every longitude spanned",
+			square: polar,
+			meters: 10,
+
+			expected: w3w.Square{
+				Southwest: w3w.LatLng{Lat: 89.99999 - 10/111195.08, Lng: -180},
+				Northeast: w3w.LatLng{Lat: 90, Lng: 180},
+			},
+		},
+		{
+			desc:   "given a square at the pole shrunk, its centre returned",
+			square: polar,
+			meters: -10,
+
+			expected: w3w.Square{
+				Southwest: polar.Center(),
+				Northeast: polar.Center(),
+			},
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			expanded := tt.square.Expand(tt.meters)
+
+			assert.InDelta(t, tt.expected.Southwest.Lat, expanded.Southwest.Lat, 1e-9)
+			assert.InDelta(t, tt.expected.Southwest.Lng, expanded.Southwest.Lng, 1e-9)
+			assert.InDelta(t, tt.expected.Northeast.Lat, expanded.Northeast.Lat, 1e-9)
+			assert.InDelta(t, tt.expected.Northeast.Lng, expanded.Northeast.Lng, 1e-9)
+		})
+	}
+}
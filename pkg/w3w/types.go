@@ -5,56 +5,50 @@ import "github.com/jonnypillar/what3words/internal/api"
 // Words ...
 type Words [3]string
 
-// Coordinates ...
-type Coordinates struct {
-	Lat float64
-	Lng float64
+// LatLng is a point on the globe in WGS84 degrees
+type LatLng struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
 }
 
+// Coordinates is an alias of LatLng, the point converted by GetWords
+type Coordinates = LatLng
+
 // Result defines a W3W result
 type Result struct {
 	Country      string `json:"country"`
 	Square       Square `json:"square"`
 	NearestPlace string `json:"nearestPlace"`
-	Coordinates  Coords `json:"coordinates"`
+	Coordinates  LatLng `json:"coordinates"`
 	Words        string `json:"words"`
 	Language     string `json:"language"`
 	Map          string `json:"map"`
 }
 
-// Square ...
+// Square is a what3words grid square
 type Square struct {
-	Southwest Southwest `json:"southwest"`
-	Northeast Northeast `json:"northeast"`
+	Southwest LatLng `json:"southwest"`
+	Northeast LatLng `json:"northeast"`
 }
 
-// Southwest ...
-type Southwest struct {
-	Lng float64 `json:"lng"`
-	Lat float64 `json:"lat"`
-}
+// Southwest is an alias of LatLng, the south west corner of a Square
+type Southwest = LatLng
 
-// Northeast ...
-type Northeast struct {
-	Lng float64 `json:"lng"`
-	Lat float64 `json:"lat"`
-}
+// Northeast is an alias of LatLng, the north east corner of a Square
+type Northeast = LatLng
 
-// Coords ...
-type Coords struct {
-	Lng float64 `json:"lng"`
-	Lat float64 `json:"lat"`
-}
+// Coords is an alias of LatLng, the centre of a Result's Square
+type Coords = LatLng
 
 func newResponse(r *api.Response) Result {
 	return Result{
 		Country: r.Country,
 		Square: Square{
-			Southwest: r.Square.Southwest,
-			Northeast: r.Square.Northeast,
+			Southwest: LatLng{Lat: r.Square.Southwest.Lat, Lng: r.Square.Southwest.Lng},
+			Northeast: LatLng{Lat: r.Square.Northeast.Lat, Lng: r.Square.Northeast.Lng},
 		},
 		NearestPlace: r.NearestPlace,
-		Coordinates:  r.Coordinates,
+		Coordinates:  LatLng{Lat: r.Coordinates.Lat, Lng: r.Coordinates.Lng},
 		Words:        r.Words,
 		Language:     r.Language,
 		Map:          r.Map,
@@ -1,8 +1,8 @@
 package w3w
 
 import (
-	"errors"
-	"fmt"
+	"context"
+	"net/http"
 	"strings"
 
 	"github.com/jonnypillar/what3words/internal/api"
@@ -16,6 +16,7 @@ const (
 	formatJSON    = "json"
 	formatGeoJSON = "geojson"
 
+	paramKey         = "key"
 	paramWords       = "words"
 	paramCoordinates = "coordinates"
 	paramFormat      = "format"
@@ -24,80 +25,111 @@ const (
 
 // Client defines the W3W Client
 type Client struct {
-	key string
+	key        string
+	httpClient *http.Client
+	logger     Logger
+	logLevel   LogLevel
+	middleware []Middleware
+	keyPool    *KeyPool
 }
 
 // New initalises a new Client instance
 // The `key` parameter sets the API Key used to authenticate against W3W APIs.
 // For information on how to get this value see https://accounts.what3words.com/en/account/developer
 //
-// If no API Key is provided, ErrNoAPIKey is returned
-func New(key string) (*Client, error) {
-	if key == "" || strings.TrimSpace(key) == "" {
+// If no API Key is provided, ErrNoAPIKey is returned, unless a key pool is
+// set with WithKeyPool. The key can be overridden per call through the call's
+// options or ContextWithKey.
+func New(key string, opts ...Option) (*Client, error) {
+	c := &Client{
+		key: key,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if strings.TrimSpace(key) == "" && c.keyPool == nil {
 		return nil, ErrNoAPIKey
 	}
 
-	return &Client{
-		key: key,
-	}, nil
+	if c.logger != nil {
+		c.httpClient = withLogging(c.httpClient, c.logger, c.logLevel)
+	}
+
+	return c, nil
 }
 
 // GetCoordinates converts a 3 word address into a Longitude and Latitude along with the country,
 // the bounds of the grid square, a nearby place and a link to the W3W site
 func (c Client) GetCoordinates(req Words, options CoordinateOptions) (Result, error) {
-	url, err := c.coordinatesURL(req, options)
+	return c.GetCoordinatesContext(context.Background(), req, options)
+}
+
+// GetCoordinatesContext is GetCoordinates with a context, which cancels the request when done
+func (c Client) GetCoordinatesContext(ctx context.Context, req Words, options CoordinateOptions) (Result, error) {
+	// validated before a key is picked so invalid input doesn't count
+	// towards a key pool's usage
+	err := ValidateWords(req)
 	if err != nil {
 		return Result{}, err
 	}
 
-	resp, err := api.Get(url)
+	key, err := c.selectKey(ctx, options.Key)
 	if err != nil {
-		var apiErr api.ErrorResponse
-
-		if errors.As(err, &apiErr) {
-			return Result{}, newResponseError(apiErr)
-		}
+		return Result{}, err
+	}
 
+	url, err := c.coordinatesURL(key, req, options)
+	if err != nil {
 		return Result{}, err
 	}
 
-	return newResponse(resp), nil
+	return c.get(ctx, key, url)
 }
 
 // GetWords converts a Longitude and Latitude into a 3 word address along with the country,
 // the bounds of the grid square, a nearby place and a link to the W3W site
-func (c Client) GetWords(req Coordinates, opts WordOptions) (Result, error) {
-	url, err := c.wordsURL(req, opts)
+func (c Client) GetWords(req LatLng, opts WordOptions) (Result, error) {
+	return c.GetWordsContext(context.Background(), req, opts)
+}
+
+// GetWordsContext is GetWords with a context, which cancels the request when done
+func (c Client) GetWordsContext(ctx context.Context, req LatLng, opts WordOptions) (Result, error) {
+	if opts.ClampLatitude {
+		req = req.Clamp()
+	}
+	if opts.NormaliseLongitude {
+		req = req.Normalise()
+	}
+
+	// validated before a key is picked so invalid input doesn't count
+	// towards a key pool's usage
+	err := req.Validate()
 	if err != nil {
 		return Result{}, err
 	}
 
-	resp, err := api.Get(url)
+	key, err := c.selectKey(ctx, opts.Key)
 	if err != nil {
-		var apiErr api.ErrorResponse
-
-		if errors.As(err, &apiErr) {
-			return Result{}, newResponseError(apiErr)
-		}
-
 		return Result{}, err
 	}
 
-	return newResponse(resp), nil
-}
-
-func (c Client) coordinatesURL(req Words, opts CoordinateOptions) (string, error) {
-	url, err := api.NewURL(c.key, opts.APIURL, convertToCoordinatesRoute)
+	url, err := c.wordsURL(key, req, opts)
 	if err != nil {
-		return "", err
+		return Result{}, err
 	}
 
-	err = validateWordParam(req)
+	return c.get(ctx, key, url)
+}
+
+func (c Client) coordinatesURL(key string, req Words, opts CoordinateOptions) (string, error) {
+	url, err := api.NewURL(key, opts.APIURL, convertToCoordinatesRoute)
 	if err != nil {
 		return "", err
 	}
 
-	url.AddParam(paramWords, strings.Join(req[:], wordsDelimiter))
+	url.AddParam(paramWords, req.String())
 
 	switch opts.Format {
 	case formatGeoJSON:
@@ -109,13 +141,13 @@ func (c Client) coordinatesURL(req Words, opts CoordinateOptions) (string, error
 	return url.URL(), nil
 }
 
-func (c Client) wordsURL(req Coordinates, opts WordOptions) (string, error) {
-	url, err := api.NewURL(c.key, opts.APIURL, convertToWordsRoute)
+func (c Client) wordsURL(key string, req LatLng, opts WordOptions) (string, error) {
+	url, err := api.NewURL(key, opts.APIURL, convertToWordsRoute)
 	if err != nil {
 		return "", err
 	}
 
-	url.AddParam(paramCoordinates, fmt.Sprintf("%f,%f", req.Lat, req.Lng))
+	url.AddParam(paramCoordinates, req.String())
 
 	if opts.Language != "" {
 		url.AddParam(paramLanguage, opts.Language)
@@ -130,15 +162,3 @@ func (c Client) wordsURL(req Coordinates, opts WordOptions) (string, error) {
 
 	return url.URL(), nil
 }
-
-func validateWordParam(req Words) error {
-	var count int
-	for _, word := range req {
-		if word == "" || strings.TrimSpace(word) == "" {
-			return ErrEmptyWord
-		}
-		count++
-	}
-
-	return nil
-}
@@ -1,8 +1,11 @@
 package w3w_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -101,6 +104,13 @@ func TestGetCoordinates(t *testing.T) {
 
 			expectedErr: w3w.ErrEmptyWord,
 		},
+		{
+			desc:   "given a word contains digits, error returned",
+			apiKey: apiKey,
+			words:  w3w.Words{"one", "two", "3"},
+
+			expectedErr: w3w.ErrInvalidWord,
+		},
 		{
 			desc:   "given words with getjson format option, request made with format option set & words returned",
 			apiKey: apiKey,
@@ -188,11 +198,13 @@ func TestGetCoordinates(t *testing.T) {
 
 func TestGetWords(t *testing.T) {
 	tests := []struct {
-		desc     string
-		apiKey   string
-		language string
-		format   string
-		coords   w3w.Coordinates
+		desc      string
+		apiKey    string
+		language  string
+		format    string
+		coords    w3w.Coordinates
+		clamp     bool
+		normalise bool
 
 		apiResponse   interface{}
 		apiStatusCode int
@@ -279,6 +291,74 @@ func TestGetWords(t *testing.T) {
 			expectedAPIURL: "/convert-to-3wa?coordinates=51.432393%2C-0.348023&format=json&key=foobar",
 			expectedErr:    fmt.Errorf("BadCoordinates: coordinates must be two comma separated lat,lng coordinates"),
 		},
+		{
+			desc:   "given coordinates with more than 6 decimals, request made with full precision",
+			apiKey: apiKey,
+			coords: w3w.Coordinates{
+				Lat: 51.52084736,
+				Lng: -0.1955213,
+			},
+
+			apiResponse: api.Response{
+				Words: "one.two.three",
+			},
+			apiStatusCode: http.StatusOK,
+
+			expectedAPIURL: "/convert-to-3wa?coordinates=51.52084736%2C-0.1955213&format=json&key=foobar",
+			expectedWords: w3w.Result{
+				Words: "one.two.three",
+			},
+		},
+		{
+			desc:      "given out of range coordinates with clamp & normalise options, request made with corrected coordinates",
+			apiKey:    apiKey,
+			clamp:     true,
+			normalise: true,
+			coords: w3w.Coordinates{
+				Lat: 90.0001,
+				Lng: 190.5,
+			},
+
+			apiResponse: api.Response{
+				Words: "one.two.three",
+			},
+			apiStatusCode: http.StatusOK,
+
+			expectedAPIURL: "/convert-to-3wa?coordinates=90%2C-169.5&format=json&key=foobar",
+			expectedWords: w3w.Result{
+				Words: "one.two.three",
+			},
+		},
+		{
+			desc:   "given a NaN latitude, error returned",
+			apiKey: apiKey,
+			coords: w3w.Coordinates{
+				Lat: math.NaN(),
+				Lng: -0.348023,
+			},
+
+			expectedErr: w3w.ErrInvalidCoordinates,
+		},
+		{
+			desc:   "given an out of range latitude, error returned",
+			apiKey: apiKey,
+			coords: w3w.Coordinates{
+				Lat: 91,
+				Lng: -0.348023,
+			},
+
+			expectedErr: w3w.ErrLatitudeOutOfRange,
+		},
+		{
+			desc:   "given an out of range longitude, error returned",
+			apiKey: apiKey,
+			coords: w3w.Coordinates{
+				Lat: 51.432393,
+				Lng: -180.5,
+			},
+
+			expectedErr: w3w.ErrLongitudeOutOfRange,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -299,9 +379,11 @@ func TestGetWords(t *testing.T) {
 			words, err := c.GetWords(
 				tt.coords,
 				w3w.WordOptions{
-					APIURL:   s.URL,
-					Language: tt.language,
-					Format:   tt.format,
+					APIURL:             s.URL,
+					Language:           tt.language,
+					Format:             tt.format,
+					ClampLatitude:      tt.clamp,
+					NormaliseLongitude: tt.normalise,
 				},
 			)
 
@@ -316,6 +398,129 @@ func TestGetWords(t *testing.T) {
 	}
 }
 
+func TestContextCancelled(t *testing.T) {
+	s := testServer(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+	defer s.Close()
+
+	c, err := w3w.New("foobar")
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = c.GetCoordinatesContext(ctx, w3w.Words{"filled", "count", "soap"}, w3w.CoordinateOptions{APIURL: s.URL})
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	_, err = c.GetWordsContext(ctx, w3w.Coordinates{Lat: 51.520847, Lng: -0.195521}, w3w.WordOptions{APIURL: s.URL})
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestLogger(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		level   *w3w.LogLevel
+		handler func(w http.ResponseWriter, r *http.Request)
+
+		expectedLevels []w3w.LogLevel
+	}{
+		{
+			desc: "given the default level and a successful request, finish logged at info",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(api.Response{Words: "filled.count.soap"})
+			},
+
+			expectedLevels: []w3w.LogLevel{w3w.LogLevelInfo},
+		},
+		{
+			desc:  "given the debug level and an error response, start and finish logged",
+			level: logLevel(w3w.LogLevelDebug),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":{"code":"BadWords","message":"Invalid or non-existent 3 word address"}}`))
+			},
+
+			expectedLevels: []w3w.LogLevel{w3w.LogLevelDebug, w3w.LogLevelWarn},
+		},
+		{
+			desc:  "given the error level and a successful request, nothing logged",
+			level: logLevel(w3w.LogLevelError),
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(api.Response{Words: "filled.count.soap"})
+			},
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := testServer(tt.handler)
+			defer s.Close()
+
+			var (
+				levels []w3w.LogLevel
+				args   []interface{}
+			)
+			opts := []w3w.Option{
+				w3w.WithLogger(w3w.LoggerFunc(func(_ context.Context, level w3w.LogLevel, _ string, a ...interface{}) {
+					levels = append(levels, level)
+					args = append(args, a...)
+				})),
+			}
+			if tt.level != nil {
+				opts = append(opts, w3w.WithLogLevel(*tt.level))
+			}
+
+			c, err := w3w.New("foobar", opts...)
+			assert.Nil(t, err)
+
+			c.GetCoordinates(w3w.Words{"filled", "count", "soap"}, w3w.CoordinateOptions{APIURL: s.URL})
+
+			assert.Equal(t, tt.expectedLevels, levels)
+			assert.NotContains(t, fmt.Sprint(args...), "foobar")
+		})
+	}
+}
+
+func TestLoggerBodyReadError(t *testing.T) {
+	// the body is cut short of its declared length
+	s := testServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":`))
+	})
+	defer s.Close()
+
+	get := func(opts ...w3w.Option) error {
+		c, err := w3w.New("foobar", opts...)
+		assert.Nil(t, err)
+
+		_, err = c.GetCoordinates(w3w.Words{"filled", "count", "soap"}, w3w.CoordinateOptions{APIURL: s.URL})
+		return err
+	}
+
+	var (
+		levels []w3w.LogLevel
+		args   []interface{}
+	)
+	logged := get(w3w.WithLogger(w3w.LoggerFunc(func(_ context.Context, level w3w.LogLevel, _ string, a ...interface{}) {
+		levels = append(levels, level)
+		args = append(args, a...)
+	})))
+
+	assert.Equal(t, []w3w.LogLevel{w3w.LogLevelWarn}, levels)
+	assert.Contains(t, args, w3w.LogKeyError)
+
+	// the client reports the failure as it does without a logger
+	unlogged := get()
+	assert.NotNil(t, unlogged)
+	assert.Equal(t, unlogged.Error(), logged.Error())
+}
+
+func logLevel(l w3w.LogLevel) *w3w.LogLevel {
+	return &l
+}
+
 func testServer(h func(w http.ResponseWriter, r *http.Request)) *httptest.Server {
 	server := httptest.NewServer(
 		http.HandlerFunc(h),
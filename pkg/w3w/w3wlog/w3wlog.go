@@ -0,0 +1,78 @@
+// Package w3wlog adapts common loggers to w3w.Logger:
+//
+//	c, _ := w3w.New(key,
+//		w3w.WithLogger(w3wlog.Slog(slog.Default())),
+//		w3w.WithLogLevel(w3w.LogLevelDebug),
+//	)
+package w3wlog
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+)
+
+// Slog returns a w3w.Logger writing to l, or slog.Default() when nil
+func Slog(l *slog.Logger) w3w.Logger {
+	return w3w.LoggerFunc(func(ctx context.Context, level w3w.LogLevel, msg string, args ...interface{}) {
+		logger := l
+		if logger == nil {
+			logger = slog.Default()
+		}
+
+		logger.Log(ctx, slog.Level(level), msg, args...)
+	})
+}
+
+// Std returns a w3w.Logger writing lines such as
+//
+//	INFO w3w request finished route=convert-to-3wa status=200 duration=41ms
+//
+// to l, or the standard logger when nil
+func Std(l *log.Logger) w3w.Logger {
+	return w3w.LoggerFunc(func(_ context.Context, level w3w.LogLevel, msg string, args ...interface{}) {
+		logger := l
+		if logger == nil {
+			logger = log.Default()
+		}
+
+		logger.Print(format(level, msg, args))
+	})
+}
+
+// format renders a record as its level, message and key=value pairs,
+// quoting values which contain spaces
+func format(level w3w.LogLevel, msg string, args []interface{}) string {
+	var b strings.Builder
+
+	b.WriteString(level.String())
+	b.WriteByte(' ')
+	b.WriteString(msg)
+
+	for i := 0; i < len(args); i += 2 {
+		key := fmt.Sprint(args[i])
+
+		var val string
+		if i+1 < len(args) {
+			val = fmt.Sprint(args[i+1])
+		} else {
+			key, val = "!BADKEY", key
+		}
+
+		if val == "" || strings.ContainsAny(val, " \t\n\"=") {
+			val = strconv.Quote(val)
+		}
+
+		b.WriteByte(' ')
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(val)
+	}
+
+	return b.String()
+}
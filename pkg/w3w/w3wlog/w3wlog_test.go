@@ -0,0 +1,78 @@
+package w3wlog_test
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"regexp"
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wlog"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdapters(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		logger func(buf *bytes.Buffer) w3w.Logger
+
+		expectedLines []string
+	}{
+		{
+			desc: "given a slog logger, records written as slog attributes",
+			logger: func(buf *bytes.Buffer) w3w.Logger {
+				return w3wlog.Slog(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+					Level: slog.LevelDebug,
+					ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+						if a.Key == slog.TimeKey || a.Key == w3w.LogKeyDuration {
+							return slog.Attr{}
+						}
+						return a
+					},
+				})))
+			},
+
+			expectedLines: []string{
+				`level=DEBUG msg="w3w request started" route=convert-to-coordinates url="http://127\.0\.0\.1:\d+/convert-to-coordinates\?format=json&key=REDACTED&words=filled\.count\.soap"`,
+				`level=WARN msg="w3w request finished" route=convert-to-coordinates url="\S+" status=400 error_code=BadWords`,
+			},
+		},
+		{
+			desc: "given a standard logger, records written as key value pairs",
+			logger: func(buf *bytes.Buffer) w3w.Logger {
+				return w3wlog.Std(log.New(buf, "", 0))
+			},
+
+			expectedLines: []string{
+				`DEBUG w3w request started route=convert-to-coordinates url="http://127\.0\.0\.1:\d+/convert-to-coordinates\?format=json&key=REDACTED&words=filled\.count\.soap"`,
+				`WARN w3w request finished route=convert-to-coordinates url="\S+" status=400 duration=\S+ error_code=BadWords`,
+			},
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := w3wtest.NewServer()
+			defer s.Close()
+
+			var buf bytes.Buffer
+			c, err := w3w.New("secret-key",
+				w3w.WithLogger(tt.logger(&buf)),
+				w3w.WithLogLevel(w3w.LogLevelDebug),
+			)
+			assert.Nil(t, err)
+
+			_, err = c.GetCoordinates(w3w.Words{"filled", "count", "soap"}, w3w.CoordinateOptions{APIURL: s.URL})
+			assert.NotNil(t, err)
+
+			lines := regexp.MustCompile("\n").Split(buf.String(), -1)
+			assert.Len(t, lines, len(tt.expectedLines)+1)
+			for i, expected := range tt.expectedLines {
+				assert.Regexp(t, "^"+expected+"$", lines[i])
+			}
+			assert.NotContains(t, buf.String(), "secret-key")
+		})
+	}
+}
@@ -0,0 +1,330 @@
+// Package w3wmock provides a mock w3w.Service which records its calls and
+// answers from expectations set by the test:
+//
+//	m := w3wmock.New()
+//	m.ExpectGetCoordinates(w3w.Words{"filled", "count", "soap"}, w3w.CoordinateOptions{}).
+//		Return(w3wtest.FilledCountSoap, nil)
+//
+//	res, err := codeUnderTest(m)
+//
+//	m.AssertExpectations(t)
+package w3wmock
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+)
+
+// Methods of the mock, used in Call and Expectation
+const (
+	MethodGetCoordinates     = "GetCoordinates"
+	MethodGetWords           = "GetWords"
+	MethodAutoSuggest        = "AutoSuggest"
+	MethodGridSection        = "GridSection"
+	MethodAvailableLanguages = "AvailableLanguages"
+)
+
+// ErrUnexpectedCall is wrapped by the error returned for calls which match no
+// expectation
+var ErrUnexpectedCall = fmt.Errorf("unexpected call")
+
+// TestingT is the part of testing.TB used to report unmet expectations
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Call is a recorded call to the mock, Args holding the request and its
+// options
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Expectation is an expected call and the result it returns
+type Expectation struct {
+	method  string
+	args    []interface{}
+	anyArgs bool
+
+	result      w3w.Result
+	suggestions []w3w.Suggestion
+	lines       []w3w.Line
+	languages   []w3w.Language
+	err         error
+
+	times int
+	calls int
+}
+
+// Return sets the result and error returned by the expected call
+func (e *Expectation) Return(res w3w.Result, err error) *Expectation {
+	e.result = res
+	e.err = err
+
+	return e
+}
+
+// ReturnSuggestions sets the suggestions and error returned by an expected
+// AutoSuggest call
+func (e *Expectation) ReturnSuggestions(suggestions []w3w.Suggestion, err error) *Expectation {
+	e.suggestions = suggestions
+	e.err = err
+
+	return e
+}
+
+// ReturnLines sets the lines and error returned by an expected GridSection
+// call
+func (e *Expectation) ReturnLines(lines []w3w.Line, err error) *Expectation {
+	e.lines = lines
+	e.err = err
+
+	return e
+}
+
+// ReturnLanguages sets the languages and error returned by an expected
+// AvailableLanguages call
+func (e *Expectation) ReturnLanguages(languages []w3w.Language, err error) *Expectation {
+	e.languages = languages
+	e.err = err
+
+	return e
+}
+
+// Times limits the expectation to n calls, after which it no longer matches.
+// By default an expectation matches any number of calls.
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+
+	return e
+}
+
+// Once is shorthand for Times(1)
+func (e *Expectation) Once() *Expectation {
+	return e.Times(1)
+}
+
+// AnyArgs makes the expectation match calls with any request and options
+func (e *Expectation) AnyArgs() *Expectation {
+	e.anyArgs = true
+
+	return e
+}
+
+func (e *Expectation) matches(method string, args []interface{}) bool {
+	if e.method != method {
+		return false
+	}
+
+	if e.times > 0 && e.calls >= e.times {
+		return false
+	}
+
+	return e.anyArgs || reflect.DeepEqual(e.args, args)
+}
+
+// met reports whether the expectation has been called enough times
+func (e *Expectation) met() bool {
+	if e.times > 0 {
+		return e.calls == e.times
+	}
+
+	return e.calls > 0
+}
+
+// Converter is a mock w3w.Service. Calls are answered by the first
+// matching expectation in the order they were set, and calls matching none
+// return an error wrapping ErrUnexpectedCall. It is safe for concurrent use.
+type Converter struct {
+	mu           sync.Mutex
+	expectations []*Expectation
+	calls        []Call
+}
+
+var _ w3w.Service = (*Converter)(nil)
+
+// New returns a mock with no expectations
+func New() *Converter {
+	return &Converter{}
+}
+
+// ExpectGetCoordinates expects GetCoordinates to be called with req and opts
+func (m *Converter) ExpectGetCoordinates(req w3w.Words, opts w3w.CoordinateOptions) *Expectation {
+	return m.expect(MethodGetCoordinates, req, opts)
+}
+
+// ExpectGetWords expects GetWords to be called with req and opts
+func (m *Converter) ExpectGetWords(req w3w.LatLng, opts w3w.WordOptions) *Expectation {
+	return m.expect(MethodGetWords, req, opts)
+}
+
+// ExpectAutoSuggest expects AutoSuggest to be called with input and opts
+func (m *Converter) ExpectAutoSuggest(input string, opts w3w.AutoSuggestOptions) *Expectation {
+	return m.expect(MethodAutoSuggest, input, opts)
+}
+
+// ExpectGridSection expects GridSection to be called with box and opts
+func (m *Converter) ExpectGridSection(box w3w.Square, opts w3w.GridSectionOptions) *Expectation {
+	return m.expect(MethodGridSection, box, opts)
+}
+
+// ExpectAvailableLanguages expects AvailableLanguages to be called with opts
+func (m *Converter) ExpectAvailableLanguages(opts w3w.LanguageOptions) *Expectation {
+	return m.expect(MethodAvailableLanguages, opts)
+}
+
+// GetCoordinates implements w3w.CoordinatesConverter
+func (m *Converter) GetCoordinates(req w3w.Words, opts w3w.CoordinateOptions) (w3w.Result, error) {
+	return m.call(MethodGetCoordinates, req, opts)
+}
+
+// GetCoordinatesContext implements w3w.CoordinatesConverter, answering as
+// GetCoordinates whatever the context
+func (m *Converter) GetCoordinatesContext(_ context.Context, req w3w.Words, opts w3w.CoordinateOptions) (w3w.Result, error) {
+	return m.call(MethodGetCoordinates, req, opts)
+}
+
+// GetWords implements w3w.WordsConverter
+func (m *Converter) GetWords(req w3w.LatLng, opts w3w.WordOptions) (w3w.Result, error) {
+	return m.call(MethodGetWords, req, opts)
+}
+
+// GetWordsContext implements w3w.WordsConverter, answering as GetWords
+// whatever the context
+func (m *Converter) GetWordsContext(_ context.Context, req w3w.LatLng, opts w3w.WordOptions) (w3w.Result, error) {
+	return m.call(MethodGetWords, req, opts)
+}
+
+// AutoSuggest implements w3w.Suggester
+func (m *Converter) AutoSuggest(input string, opts w3w.AutoSuggestOptions) ([]w3w.Suggestion, error) {
+	e, err := m.match(MethodAutoSuggest, input, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.suggestions, e.err
+}
+
+// AutoSuggestContext implements w3w.Suggester, answering as AutoSuggest
+// whatever the context
+func (m *Converter) AutoSuggestContext(_ context.Context, input string, opts w3w.AutoSuggestOptions) ([]w3w.Suggestion, error) {
+	return m.AutoSuggest(input, opts)
+}
+
+// GridSection implements w3w.GridSectioner
+func (m *Converter) GridSection(box w3w.Square, opts w3w.GridSectionOptions) ([]w3w.Line, error) {
+	e, err := m.match(MethodGridSection, box, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.lines, e.err
+}
+
+// GridSectionContext implements w3w.GridSectioner, answering as GridSection
+// whatever the context
+func (m *Converter) GridSectionContext(_ context.Context, box w3w.Square, opts w3w.GridSectionOptions) ([]w3w.Line, error) {
+	return m.GridSection(box, opts)
+}
+
+// AvailableLanguages implements w3w.LanguageLister
+func (m *Converter) AvailableLanguages(opts w3w.LanguageOptions) ([]w3w.Language, error) {
+	e, err := m.match(MethodAvailableLanguages, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.languages, e.err
+}
+
+// AvailableLanguagesContext implements w3w.LanguageLister, answering as
+// AvailableLanguages whatever the context
+func (m *Converter) AvailableLanguagesContext(_ context.Context, opts w3w.LanguageOptions) ([]w3w.Language, error) {
+	return m.AvailableLanguages(opts)
+}
+
+// Calls returns the calls made to the mock in order
+func (m *Converter) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]Call, len(m.calls))
+	copy(calls, m.calls)
+
+	return calls
+}
+
+// AssertExpectations reports each expectation which was not called, or not
+// called the number of times set, as an error on t. It returns whether all
+// expectations were met.
+func (m *Converter) AssertExpectations(t TestingT) bool {
+	t.Helper()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ok := true
+	for _, e := range m.expectations {
+		if e.met() {
+			continue
+		}
+		ok = false
+
+		if e.times > 0 {
+			t.Errorf("w3wmock: expected %s%v to be called %d times, called %d times", e.method, e.args, e.times, e.calls)
+		} else {
+			t.Errorf("w3wmock: expected %s%v to be called", e.method, e.args)
+		}
+	}
+
+	return ok
+}
+
+func (m *Converter) expect(method string, args ...interface{}) *Expectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e := &Expectation{
+		method: method,
+		args:   args,
+	}
+	m.expectations = append(m.expectations, e)
+
+	return e
+}
+
+func (m *Converter) call(method string, args ...interface{}) (w3w.Result, error) {
+	e, err := m.match(method, args...)
+	if err != nil {
+		return w3w.Result{}, err
+	}
+
+	return e.result, e.err
+}
+
+// match records a call and returns the first expectation it matches
+func (m *Converter) match(method string, args ...interface{}) (*Expectation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, Call{
+		Method: method,
+		Args:   args,
+	})
+
+	for _, e := range m.expectations {
+		if !e.matches(method, args) {
+			continue
+		}
+		e.calls++
+
+		return e, nil
+	}
+
+	return nil, fmt.Errorf("%w %s%v", ErrUnexpectedCall, method, args)
+}
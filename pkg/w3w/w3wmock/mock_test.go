@@ -0,0 +1,208 @@
+package w3wmock_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wmock"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder is a TestingT recording reported errors
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+var (
+	words  = w3w.Words{"filled", "count", "soap"}
+	coords = w3w.Coordinates{Lat: 51.520847, Lng: -0.195521}
+)
+
+func TestConverter(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		expect func(m *w3wmock.Converter)
+		call   func(c w3w.Converter) (w3w.Result, error)
+
+		expectedResult w3w.Result
+		expectedErr    error
+	}{
+		{
+			desc: "given a matching GetCoordinates expectation, result returned",
+			expect: func(m *w3wmock.Converter) {
+				m.ExpectGetCoordinates(words, w3w.CoordinateOptions{}).Return(w3wtest.FilledCountSoap, nil)
+			},
+			call: func(c w3w.Converter) (w3w.Result, error) {
+				return c.GetCoordinates(words, w3w.CoordinateOptions{})
+			},
+
+			expectedResult: w3wtest.FilledCountSoap,
+		},
+		{
+			desc: "given a matching GetWords expectation, error returned",
+			expect: func(m *w3wmock.Converter) {
+				m.ExpectGetWords(coords, w3w.WordOptions{Language: "de"}).Return(w3w.Result{}, w3w.Error{Code: "BadLanguage"})
+			},
+			call: func(c w3w.Converter) (w3w.Result, error) {
+				return c.GetWords(coords, w3w.WordOptions{Language: "de"})
+			},
+
+			expectedErr: w3w.Error{Code: "BadLanguage"},
+		},
+		{
+			desc: "given an expectation with other options, ErrUnexpectedCall returned",
+			expect: func(m *w3wmock.Converter) {
+				m.ExpectGetWords(coords, w3w.WordOptions{}).Return(w3wtest.FilledCountSoap, nil)
+			},
+			call: func(c w3w.Converter) (w3w.Result, error) {
+				return c.GetWords(coords, w3w.WordOptions{Language: "fr"})
+			},
+
+			expectedErr: w3wmock.ErrUnexpectedCall,
+		},
+		{
+			desc: "given an expectation with any args, result returned",
+			expect: func(m *w3wmock.Converter) {
+				m.ExpectGetWords(w3w.Coordinates{}, w3w.WordOptions{}).AnyArgs().Return(w3wtest.FilledCountSoap, nil)
+			},
+			call: func(c w3w.Converter) (w3w.Result, error) {
+				return c.GetWords(coords, w3w.WordOptions{Language: "fr"})
+			},
+
+			expectedResult: w3wtest.FilledCountSoap,
+		},
+		{
+			desc: "given a used up expectation, next expectation returned",
+			expect: func(m *w3wmock.Converter) {
+				m.ExpectGetCoordinates(words, w3w.CoordinateOptions{}).Return(w3w.Result{}, w3w.Error{Code: "QuotaExceeded"}).Once()
+				m.ExpectGetCoordinates(words, w3w.CoordinateOptions{}).Return(w3wtest.FilledCountSoap, nil)
+
+				m.GetCoordinates(words, w3w.CoordinateOptions{})
+			},
+			call: func(c w3w.Converter) (w3w.Result, error) {
+				return c.GetCoordinates(words, w3w.CoordinateOptions{})
+			},
+
+			expectedResult: w3wtest.FilledCountSoap,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			m := w3wmock.New()
+			tt.expect(m)
+
+			res, err := tt.call(m)
+
+			if tt.expectedErr != nil {
+				assert.True(t, errors.Is(err, tt.expectedErr), "got %v", err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectedResult, res)
+			}
+		})
+	}
+}
+
+func TestConverterCalls(t *testing.T) {
+	m := w3wmock.New()
+
+	m.GetCoordinates(words, w3w.CoordinateOptions{Format: "geojson"})
+	m.GetWords(coords, w3w.WordOptions{})
+
+	assert.Equal(t, []w3wmock.Call{
+		{Method: w3wmock.MethodGetCoordinates, Args: []interface{}{words, w3w.CoordinateOptions{Format: "geojson"}}},
+		{Method: w3wmock.MethodGetWords, Args: []interface{}{coords, w3w.WordOptions{}}},
+	}, m.Calls())
+}
+
+func TestConverterAssertExpectations(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		calls int
+
+		expectedOK     bool
+		expectedErrors int
+	}{
+		{
+			desc:  "given every expectation called, no errors reported",
+			calls: 2,
+
+			expectedOK: true,
+		},
+		{
+			desc:  "given an expectation called too few times, errors reported",
+			calls: 1,
+
+			expectedErrors: 1,
+		},
+		{
+			desc:  "given no calls, errors reported",
+			calls: 0,
+
+			expectedErrors: 2,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			m := w3wmock.New()
+			m.ExpectGetCoordinates(words, w3w.CoordinateOptions{}).Times(2)
+			m.ExpectGetWords(coords, w3w.WordOptions{})
+
+			for i := 0; i < tt.calls; i++ {
+				m.GetCoordinates(words, w3w.CoordinateOptions{})
+			}
+			if tt.calls > 0 {
+				m.GetWords(coords, w3w.WordOptions{})
+			}
+
+			r := &recorder{}
+			ok := m.AssertExpectations(r)
+
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Len(t, r.errors, tt.expectedErrors)
+		})
+	}
+}
+
+func TestConverterService(t *testing.T) {
+	suggestions := []w3w.Suggestion{{Words: "filled.count.soap", Rank: 1}}
+	lines := []w3w.Line{{Start: coords, End: coords}}
+	languages := []w3w.Language{{Code: "en", Name: "English", NativeName: "English"}}
+	box := w3wtest.FilledCountSoap.Square
+
+	m := w3wmock.New()
+	m.ExpectAutoSuggest("filled.count.so", w3w.AutoSuggestOptions{}).ReturnSuggestions(suggestions, nil)
+	m.ExpectGridSection(box, w3w.GridSectionOptions{}).ReturnLines(lines, nil)
+	m.ExpectAvailableLanguages(w3w.LanguageOptions{}).ReturnLanguages(languages, nil)
+
+	var s w3w.Service = m
+
+	gotSuggestions, err := s.AutoSuggest("filled.count.so", w3w.AutoSuggestOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, suggestions, gotSuggestions)
+
+	gotLines, err := s.GridSection(box, w3w.GridSectionOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, lines, gotLines)
+
+	gotLanguages, err := s.AvailableLanguages(w3w.LanguageOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, languages, gotLanguages)
+
+	_, err = s.AutoSuggest("filled.count", w3w.AutoSuggestOptions{})
+	assert.True(t, errors.Is(err, w3wmock.ErrUnexpectedCall))
+
+	assert.True(t, m.AssertExpectations(t))
+	assert.Len(t, m.Calls(), 4)
+}
@@ -0,0 +1,152 @@
+// Package w3wotel instruments the w3w client with OpenTelemetry tracing.
+//
+// NewTransport returns an http.RoundTripper which creates a client span for
+// each API call, recording its route, language, format, HTTP status and
+// what3words error code. The API key is never recorded. Spans are children
+// of the span in the context passed to the client's Context methods, and the
+// trace context is propagated to the API in the request headers:
+//
+//	hc := &http.Client{Transport: w3wotel.NewTransport(nil)}
+//	c, _ := w3w.New(key, w3w.WithHTTPClient(hc))
+//
+//	res, err := c.GetCoordinatesContext(ctx, words, w3w.CoordinateOptions{})
+package w3wotel
+
+import (
+	"net/http"
+
+	"github.com/jonnypillar/what3words/internal/api"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ScopeName is the instrumentation scope of the tracer
+	ScopeName = "github.com/jonnypillar/what3words/pkg/w3w/w3wotel"
+
+	spanPrefix = "w3w "
+)
+
+// Attributes recorded on spans
+const (
+	AttrRoute     = attribute.Key("w3w.route")
+	AttrLanguage  = attribute.Key("w3w.language")
+	AttrFormat    = attribute.Key("w3w.format")
+	AttrErrorCode = attribute.Key("w3w.error.code")
+
+	AttrHTTPMethod     = attribute.Key("http.request.method")
+	AttrHTTPStatusCode = attribute.Key("http.response.status_code")
+	AttrServerAddress  = attribute.Key("server.address")
+)
+
+// Option configures a Transport
+type Option func(*Transport)
+
+// WithTracerProvider sets the provider of the Transport's tracer, by default
+// the global provider
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(t *Transport) {
+		t.provider = tp
+	}
+}
+
+// WithPropagators sets the propagators used to send the trace context to the
+// API, by default the global propagators
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(t *Transport) {
+		t.propagators = p
+	}
+}
+
+// Transport is an http.RoundTripper tracing the requests it passes on
+type Transport struct {
+	next        http.RoundTripper
+	provider    trace.TracerProvider
+	propagators propagation.TextMapPropagator
+	tracer      trace.Tracer
+}
+
+// NewTransport returns a Transport sending requests with next, or
+// http.DefaultTransport when nil
+func NewTransport(next http.RoundTripper, opts ...Option) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	t := &Transport{
+		next: next,
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	if t.provider == nil {
+		t.provider = otel.GetTracerProvider()
+	}
+	if t.propagators == nil {
+		t.propagators = otel.GetTextMapPropagator()
+	}
+
+	t.tracer = t.provider.Tracer(ScopeName)
+
+	return t
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	route := api.Route(req.URL)
+	q := req.URL.Query()
+
+	attrs := []attribute.KeyValue{
+		AttrRoute.String(route),
+		AttrHTTPMethod.String(req.Method),
+		AttrServerAddress.String(req.URL.Hostname()),
+	}
+	if v := q.Get("language"); v != "" {
+		attrs = append(attrs, AttrLanguage.String(v))
+	}
+	if v := q.Get("format"); v != "" {
+		attrs = append(attrs, AttrFormat.String(v))
+	}
+
+	ctx, span := t.tracer.Start(req.Context(), spanPrefix+route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	req = req.Clone(ctx)
+	t.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	span.SetAttributes(AttrHTTPStatusCode.Int(resp.StatusCode))
+
+	if resp.StatusCode != http.StatusOK {
+		// a body which can't be read is left for the client to report, as
+		// it would without tracing
+		code, err := api.ErrorCode(resp)
+		switch {
+		case err != nil:
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		case code != "":
+			span.SetAttributes(AttrErrorCode.String(code))
+			span.SetStatus(codes.Error, code)
+		default:
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,173 @@
+package w3wotel_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wotel"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const apiKey = "secret-key"
+
+func TestTransport(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		inject func(s *w3wtest.Server)
+		call   func(ctx context.Context, c *w3w.Client, apiURL string) error
+
+		expectedName   string
+		expectedAttrs  []attribute.KeyValue
+		expectedStatus codes.Code
+	}{
+		{
+			desc: "given a successful GetWords call, span with route, language and status returned",
+			call: func(ctx context.Context, c *w3w.Client, apiURL string) error {
+				_, err := c.GetWordsContext(ctx, w3w.Coordinates{Lat: 51.520847, Lng: -0.195521}, w3w.WordOptions{
+					APIURL:   apiURL,
+					Language: "en",
+				})
+				return err
+			},
+
+			expectedName: "w3w convert-to-3wa",
+			expectedAttrs: []attribute.KeyValue{
+				w3wotel.AttrRoute.String("convert-to-3wa"),
+				w3wotel.AttrLanguage.String("en"),
+				w3wotel.AttrFormat.String("json"),
+				w3wotel.AttrHTTPStatusCode.Int(200),
+			},
+			expectedStatus: codes.Unset,
+		},
+		{
+			desc: "given a GetCoordinates call failing with an API error, span with error code returned",
+			inject: func(s *w3wtest.Server) {
+				s.InjectError(w3wtest.RouteConvertToCoordinates, w3wtest.QuotaExceeded, 1)
+			},
+			call: func(ctx context.Context, c *w3w.Client, apiURL string) error {
+				_, err := c.GetCoordinatesContext(ctx, w3w.Words{"filled", "count", "soap"}, w3w.CoordinateOptions{
+					APIURL: apiURL,
+					Format: "geojson",
+				})
+				return err
+			},
+
+			expectedName: "w3w convert-to-coordinates",
+			expectedAttrs: []attribute.KeyValue{
+				w3wotel.AttrRoute.String("convert-to-coordinates"),
+				w3wotel.AttrFormat.String("geojson"),
+				w3wotel.AttrHTTPStatusCode.Int(402),
+				w3wotel.AttrErrorCode.String("QuotaExceeded"),
+			},
+			expectedStatus: codes.Error,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+			defer s.Close()
+
+			if tt.inject != nil {
+				tt.inject(s)
+			}
+
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+			var traceparent string
+			probe := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				traceparent = req.Header.Get("traceparent")
+				return http.DefaultTransport.RoundTrip(req)
+			})
+
+			c, err := w3w.New(apiKey, w3w.WithHTTPClient(&http.Client{
+				Transport: w3wotel.NewTransport(probe,
+					w3wotel.WithTracerProvider(tp),
+					w3wotel.WithPropagators(propagation.TraceContext{}),
+				),
+			}))
+			assert.Nil(t, err)
+
+			ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+			err = tt.call(ctx, c, s.URL)
+			parent.End()
+
+			if tt.expectedStatus == codes.Error {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+
+			spans := sr.Ended()
+			assert.Len(t, spans, 2)
+
+			span := spans[0]
+			assert.Equal(t, tt.expectedName, span.Name())
+			assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+			assert.Equal(t, tt.expectedStatus, span.Status().Code)
+			assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+			assert.Contains(t, traceparent, span.SpanContext().TraceID().String())
+
+			attrs := span.Attributes()
+			for _, a := range tt.expectedAttrs {
+				assert.Contains(t, attrs, a)
+			}
+			for _, a := range attrs {
+				assert.False(t, strings.Contains(a.Value.Emit(), apiKey), "attribute %s contains the key", a.Key)
+			}
+		})
+	}
+}
+
+func TestTransportBodyReadError(t *testing.T) {
+	// the body is cut short of its declared length
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":`))
+	}))
+	defer s.Close()
+
+	get := func(opts ...w3w.Option) error {
+		c, err := w3w.New(apiKey, opts...)
+		assert.Nil(t, err)
+
+		_, err = c.GetCoordinates(w3w.Words{"filled", "count", "soap"}, w3w.CoordinateOptions{APIURL: s.URL})
+		return err
+	}
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	traced := get(w3w.WithHTTPClient(&http.Client{
+		Transport: w3wotel.NewTransport(nil, w3wotel.WithTracerProvider(tp)),
+	}))
+
+	spans := sr.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), w3wotel.AttrHTTPStatusCode.Int(400))
+
+	// the client reports the failure as it does without tracing
+	untraced := get()
+	assert.NotNil(t, untraced)
+	assert.Equal(t, untraced.Error(), traced.Error())
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
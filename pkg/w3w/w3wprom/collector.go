@@ -0,0 +1,218 @@
+// Package w3wprom exposes Prometheus metrics for w3w client calls.
+//
+// A Collector is registered with a Prometheus registry and measures the
+// requests sent through its transport:
+//
+//	metrics := w3wprom.NewCollector(w3wprom.CollectorOptions{})
+//	prometheus.MustRegister(metrics)
+//
+//	hc := &http.Client{Transport: metrics.Transport(nil)}
+//	c, _ := w3w.New(key, w3w.WithHTTPClient(hc))
+//
+// The metrics, prefixed with w3w_client_ by default, are:
+//
+//	requests_total{route, status, code}   requests completed, status being
+//	                                      the HTTP status or "error" when no
+//	                                      response was received, and code the
+//	                                      what3words error code if any
+//	request_duration_seconds{route}       request latency histogram
+//	requests_in_flight{route}             requests awaiting a response
+//	response_size_bytes{route}            response body size histogram
+package w3wprom
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jonnypillar/what3words/internal/api"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	defaultNamespace = "w3w"
+	subsystem        = "client"
+
+	labelRoute  = "route"
+	labelStatus = "status"
+	labelCode   = "code"
+
+	// statusError is the status label of requests which got no response
+	statusError = "error"
+	// codeReadError is the code label of error responses whose body could
+	// not be read
+	codeReadError = "ReadError"
+)
+
+var (
+	// DefaultDurationBuckets are the request duration histogram's buckets in
+	// seconds
+	DefaultDurationBuckets = []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30}
+	// DefaultSizeBuckets are the response size histogram's buckets in bytes
+	DefaultSizeBuckets = prometheus.ExponentialBuckets(128, 2, 8)
+)
+
+// CollectorOptions configures a Collector
+type CollectorOptions struct {
+	// Namespace prefixes the metric names, w3w by default
+	Namespace       string
+	ConstLabels     prometheus.Labels
+	DurationBuckets []float64
+	SizeBuckets     []float64
+}
+
+// Collector is a prometheus.Collector of w3w client metrics
+type Collector struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+	size     *prometheus.HistogramVec
+}
+
+var _ prometheus.Collector = (*Collector)(nil)
+
+// NewCollector initialises a new Collector
+func NewCollector(opts CollectorOptions) *Collector {
+	if opts.Namespace == "" {
+		opts.Namespace = defaultNamespace
+	}
+	if opts.DurationBuckets == nil {
+		opts.DurationBuckets = DefaultDurationBuckets
+	}
+	if opts.SizeBuckets == nil {
+		opts.SizeBuckets = DefaultSizeBuckets
+	}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Subsystem:   subsystem,
+			Name:        "requests_total",
+			Help:        "Requests to the what3words API by route, HTTP status and error code.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{labelRoute, labelStatus, labelCode}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   opts.Namespace,
+			Subsystem:   subsystem,
+			Name:        "request_duration_seconds",
+			Help:        "Latency of requests to the what3words API by route.",
+			ConstLabels: opts.ConstLabels,
+			Buckets:     opts.DurationBuckets,
+		}, []string{labelRoute}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   opts.Namespace,
+			Subsystem:   subsystem,
+			Name:        "requests_in_flight",
+			Help:        "Requests to the what3words API awaiting a response by route.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{labelRoute}),
+		size: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   opts.Namespace,
+			Subsystem:   subsystem,
+			Name:        "response_size_bytes",
+			Help:        "Size of what3words API response bodies by route.",
+			ConstLabels: opts.ConstLabels,
+			Buckets:     opts.SizeBuckets,
+		}, []string{labelRoute}),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.duration.Describe(ch)
+	c.inFlight.Describe(ch)
+	c.size.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.duration.Collect(ch)
+	c.inFlight.Collect(ch)
+	c.size.Collect(ch)
+}
+
+// Transport returns an http.RoundTripper measuring the requests it sends
+// with next, or http.DefaultTransport when nil. Requests are measured until
+// their response body is closed, so the duration and size cover reading it.
+func (c *Collector) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return c.roundTrip(next, req)
+	})
+}
+
+func (c *Collector) roundTrip(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	route := api.Route(req.URL)
+	start := time.Now()
+
+	inFlight := c.inFlight.WithLabelValues(route)
+	inFlight.Inc()
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		inFlight.Dec()
+		c.duration.WithLabelValues(route).Observe(time.Since(start).Seconds())
+		c.requests.WithLabelValues(route, statusError, "").Inc()
+
+		return nil, err
+	}
+
+	// a body which can't be read is left for the client to report, as it
+	// would without the collector
+	code, err := api.ErrorCode(resp)
+	if err != nil {
+		code = codeReadError
+	}
+
+	status := strconv.Itoa(resp.StatusCode)
+	resp.Body = &measuredBody{
+		ReadCloser: resp.Body,
+		done: func(size int64) {
+			inFlight.Dec()
+			c.duration.WithLabelValues(route).Observe(time.Since(start).Seconds())
+			c.size.WithLabelValues(route).Observe(float64(size))
+			c.requests.WithLabelValues(route, status, code).Inc()
+		},
+	}
+
+	return resp, nil
+}
+
+// measuredBody counts the bytes read from a response body, calling done
+// once when it is closed
+type measuredBody struct {
+	io.ReadCloser
+
+	size int64
+	done func(size int64)
+}
+
+func (b *measuredBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += int64(n)
+
+	return n, err
+}
+
+func (b *measuredBody) Close() error {
+	err := b.ReadCloser.Close()
+
+	if b.done != nil {
+		b.done(b.size)
+		b.done = nil
+	}
+
+	return err
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,111 @@
+package w3wprom_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wprom"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollector(t *testing.T) {
+	s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+	defer s.Close()
+
+	s.InjectError(w3wtest.RouteConvertToCoordinates, w3wtest.QuotaExceeded, 1)
+
+	metrics := w3wprom.NewCollector(w3wprom.CollectorOptions{})
+	reg := prometheus.NewPedanticRegistry()
+	assert.Nil(t, reg.Register(metrics))
+
+	c, err := w3w.New("foobar", w3w.WithHTTPClient(&http.Client{
+		Transport: metrics.Transport(nil),
+	}))
+	assert.Nil(t, err)
+
+	words := w3w.Words{"filled", "count", "soap"}
+	for i := 0; i < 3; i++ {
+		c.GetCoordinates(words, w3w.CoordinateOptions{APIURL: s.URL})
+	}
+	c.GetWords(w3w.Coordinates{Lat: 51.520847, Lng: -0.195521}, w3w.WordOptions{APIURL: s.URL})
+
+	dropped, _ := w3w.New("foobar", w3w.WithHTTPClient(&http.Client{
+		Transport: metrics.Transport(w3wtest.NewChaos(nil, w3wtest.ChaosConfig{DropRate: 1})),
+	}))
+	dropped.GetWords(w3w.Coordinates{Lat: 51.520847, Lng: -0.195521}, w3w.WordOptions{APIURL: s.URL})
+
+	expected := `
+# HELP w3w_client_requests_in_flight Requests to the what3words API awaiting a response by route.
+# TYPE w3w_client_requests_in_flight gauge
+w3w_client_requests_in_flight{route="convert-to-3wa"} 0
+w3w_client_requests_in_flight{route="convert-to-coordinates"} 0
+# HELP w3w_client_requests_total Requests to the what3words API by route, HTTP status and error code.
+# TYPE w3w_client_requests_total counter
+w3w_client_requests_total{code="",route="convert-to-3wa",status="200"} 1
+w3w_client_requests_total{code="",route="convert-to-3wa",status="error"} 1
+w3w_client_requests_total{code="",route="convert-to-coordinates",status="200"} 2
+w3w_client_requests_total{code="QuotaExceeded",route="convert-to-coordinates",status="402"} 1
+`
+	assert.Nil(t, testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"w3w_client_requests_total",
+		"w3w_client_requests_in_flight",
+	))
+
+	// a series per route
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics, "w3w_client_request_duration_seconds"))
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics, "w3w_client_response_size_bytes"))
+
+	lint, err := testutil.GatherAndLint(reg)
+	assert.Nil(t, err)
+	assert.Empty(t, lint)
+}
+
+func TestCollectorBodyReadError(t *testing.T) {
+	// the body is cut short of its declared length
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":`))
+	}))
+	defer s.Close()
+
+	get := func(opts ...w3w.Option) error {
+		c, err := w3w.New("foobar", opts...)
+		assert.Nil(t, err)
+
+		_, err = c.GetCoordinates(w3w.Words{"filled", "count", "soap"}, w3w.CoordinateOptions{APIURL: s.URL})
+		return err
+	}
+
+	metrics := w3wprom.NewCollector(w3wprom.CollectorOptions{})
+	reg := prometheus.NewPedanticRegistry()
+	assert.Nil(t, reg.Register(metrics))
+
+	measured := get(w3w.WithHTTPClient(&http.Client{
+		Transport: metrics.Transport(nil),
+	}))
+
+	expected := `
+# HELP w3w_client_requests_in_flight Requests to the what3words API awaiting a response by route.
+# TYPE w3w_client_requests_in_flight gauge
+w3w_client_requests_in_flight{route="convert-to-coordinates"} 0
+# HELP w3w_client_requests_total Requests to the what3words API by route, HTTP status and error code.
+# TYPE w3w_client_requests_total counter
+w3w_client_requests_total{code="ReadError",route="convert-to-coordinates",status="400"} 1
+`
+	assert.Nil(t, testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"w3w_client_requests_total",
+		"w3w_client_requests_in_flight",
+	))
+
+	// the client reports the failure as it does without the collector
+	unmeasured := get()
+	assert.NotNil(t, unmeasured)
+	assert.Equal(t, unmeasured.Error(), measured.Error())
+}
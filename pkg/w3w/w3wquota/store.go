@@ -0,0 +1,160 @@
+package w3wquota
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Counter identifies a count of billable calls in a period, by key, route
+// and caller tag
+type Counter struct {
+	// Period is the day, as 2006-01-02, or month, as 2006-01, in UTC
+	Period string `json:"period"`
+	KeyID  string `json:"keyId"`
+	Route  string `json:"route"`
+	Tag    string `json:"tag"`
+}
+
+// Store holds the counters. Implementations must be safe for concurrent
+// use.
+type Store interface {
+	// Add adds n to the counter, returning its new value
+	Add(c Counter, n int64) (int64, error)
+	// Counts returns the counters for period
+	Counts(period string) (map[Counter]int64, error)
+}
+
+// MemoryStore is a Store held in memory
+type MemoryStore struct {
+	mu     sync.Mutex
+	counts map[Counter]int64
+}
+
+// NewMemoryStore initialises a new MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		counts: map[Counter]int64{},
+	}
+}
+
+// Add implements Store
+func (s *MemoryStore) Add(c Counter, n int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counts[c] += n
+
+	return s.counts[c], nil
+}
+
+// Counts implements Store
+func (s *MemoryStore) Counts(period string) (map[Counter]int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := map[Counter]int64{}
+	for c, n := range s.counts {
+		if c.Period == period {
+			counts[c] = n
+		}
+	}
+
+	return counts, nil
+}
+
+// FileStore is a Store persisted to a JSON file, which is rewritten after
+// every change so counts survive restarts. It suits a single process.
+type FileStore struct {
+	path string
+
+	mu  sync.Mutex
+	mem *MemoryStore
+}
+
+type fileEntry struct {
+	Counter
+	Count int64 `json:"count"`
+}
+
+// NewFileStore loads the counters saved at path, starting empty when the
+// file does not exist
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{
+		path: path,
+		mem:  NewMemoryStore(),
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading usage file %w", err)
+	}
+
+	var entries []fileEntry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("invalid usage file %s: %w", path, err)
+	}
+
+	for _, e := range entries {
+		s.mem.counts[e.Counter] = e.Count
+	}
+
+	return s, nil
+}
+
+// Add implements Store
+func (s *FileStore) Add(c Counter, n int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, _ := s.mem.Add(c, n)
+
+	return v, s.save()
+}
+
+// Counts implements Store
+func (s *FileStore) Counts(period string) (map[Counter]int64, error) {
+	return s.mem.Counts(period)
+}
+
+// save writes the counters to a temporary file and renames it over the
+// store's file
+func (s *FileStore) save() error {
+	s.mem.mu.Lock()
+	entries := make([]fileEntry, 0, len(s.mem.counts))
+	for c, n := range s.mem.counts {
+		entries = append(entries, fileEntry{Counter: c, Count: n})
+	}
+	s.mem.mu.Unlock()
+
+	b, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("error saving usage file %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error saving usage file %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error saving usage file %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("error saving usage file %w", err)
+	}
+
+	return nil
+}
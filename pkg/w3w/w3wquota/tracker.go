@@ -0,0 +1,296 @@
+// Package w3wquota tracks billable what3words API calls and enforces usage
+// budgets.
+//
+// A Tracker is added to the client as middleware. It counts successful calls
+// per key, route and caller tag in a Store, calls a function when a soft
+// budget is reached and, once a hard budget is used up, fails calls locally
+// with a *BudgetExceededError instead of calling the API:
+//
+//	store, _ := w3wquota.NewFileStore("usage.json")
+//	tracker := w3wquota.NewTracker(w3wquota.TrackerOptions{
+//		Store: store,
+//		Budgets: []w3wquota.Budget{
+//			{Period: w3wquota.Monthly, Soft: 80000, Hard: 100000},
+//		},
+//		OnSoftLimit: func(b w3wquota.Budget, used int64) { ... },
+//	})
+//
+//	c, _ := w3w.New(key, w3w.WithMiddleware(tracker.Middleware()))
+//	res, err := c.GetWordsContext(w3wquota.ContextWithTag(ctx, "billing"), coords, opts)
+//
+// Budgets are checked before each call, so concurrent calls can exceed a
+// hard budget by up to the number of calls in flight.
+package w3wquota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+)
+
+// Period is the length of a budget
+type Period int
+
+// Periods, in UTC
+const (
+	Daily Period = iota
+	Monthly
+)
+
+// key returns the counter period of t
+func (p Period) key(t time.Time) string {
+	if p == Daily {
+		return t.UTC().Format("2006-01-02")
+	}
+
+	return t.UTC().Format("2006-01")
+}
+
+// String returns the period's name
+func (p Period) String() string {
+	if p == Daily {
+		return "daily"
+	}
+
+	return "monthly"
+}
+
+// Budget limits the billable calls in a period. KeyID, Route and Tag narrow
+// the calls counted, matching every call when empty.
+type Budget struct {
+	Period Period
+	// Soft is the count at which OnSoftLimit is called, ignored when 0
+	Soft int64
+	// Hard is the count at which calls fail, ignored when 0
+	Hard int64
+
+	KeyID string
+	Route string
+	Tag   string
+}
+
+func (b Budget) matches(c Counter) bool {
+	return (b.KeyID == "" || b.KeyID == c.KeyID) &&
+		(b.Route == "" || b.Route == c.Route) &&
+		(b.Tag == "" || b.Tag == c.Tag)
+}
+
+// ErrBudgetExceeded is matched by errors.Is for a *BudgetExceededError
+var ErrBudgetExceeded = errors.New("usage budget exceeded")
+
+// BudgetExceededError is returned for calls made once a hard budget is used
+// up
+type BudgetExceededError struct {
+	Budget Budget
+	Used   int64
+}
+
+// Error ...
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("%s %s: %d of %d calls used", e.Budget.Period, ErrBudgetExceeded, e.Used, e.Budget.Hard)
+}
+
+// Is reports whether target is ErrBudgetExceeded
+func (e *BudgetExceededError) Is(target error) bool {
+	return target == ErrBudgetExceeded
+}
+
+type tagContextKey struct{}
+
+// ContextWithTag returns a copy of ctx tagging calls made with it, for
+// example with the calling service or customer
+func ContextWithTag(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, tagContextKey{}, tag)
+}
+
+// TagFromContext returns the tag set by ContextWithTag
+func TagFromContext(ctx context.Context) string {
+	tag, _ := ctx.Value(tagContextKey{}).(string)
+
+	return tag
+}
+
+// TrackerOptions configures a Tracker
+type TrackerOptions struct {
+	// Store holds the counters, in memory by default
+	Store   Store
+	Budgets []Budget
+	// OnSoftLimit is called once, by the call which reaches a soft budget
+	OnSoftLimit func(b Budget, used int64)
+	// OnError is called when a billable call can't be recorded, the call's
+	// result being returned regardless as it has already been billed
+	OnError func(err error)
+	// Now returns the current time, time.Now by default
+	Now func() time.Time
+}
+
+// Tracker counts billable calls and enforces budgets
+type Tracker struct {
+	opts TrackerOptions
+
+	// mu serialises recording so exactly one call sees a soft budget
+	// crossed
+	mu sync.Mutex
+}
+
+// NewTracker initialises a new Tracker
+func NewTracker(opts TrackerOptions) *Tracker {
+	if opts.Store == nil {
+		opts.Store = NewMemoryStore()
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	return &Tracker{
+		opts: opts,
+	}
+}
+
+// Middleware returns the w3w.Middleware which tracks calls. Calls answered
+// by the API with a 200 status are billable. Failing to record one is
+// reported to OnError rather than failing the call.
+func (t *Tracker) Middleware() w3w.Middleware {
+	return func(next w3w.Handler) w3w.Handler {
+		return func(ctx context.Context, req *w3w.Request) *w3w.Response {
+			now := t.opts.Now()
+			c := Counter{
+				KeyID: req.KeyID,
+				Route: req.Route,
+				Tag:   TagFromContext(ctx),
+			}
+
+			if err := t.check(now, c); err != nil {
+				return &w3w.Response{Err: err}
+			}
+
+			resp := next(ctx, req)
+			if resp.StatusCode != http.StatusOK {
+				return resp
+			}
+
+			if err := t.record(now, c); err != nil && t.opts.OnError != nil {
+				t.opts.OnError(fmt.Errorf("error recording usage %w", err))
+			}
+
+			return resp
+		}
+	}
+}
+
+// Usage returns the billable calls counted in the period containing at,
+// summed over the calls matched by b's KeyID, Route and Tag
+func (t *Tracker) Usage(b Budget, at time.Time) (int64, error) {
+	counts, err := t.opts.Store.Counts(b.Period.key(at))
+	if err != nil {
+		return 0, err
+	}
+
+	return sum(b, counts), nil
+}
+
+// check returns a *BudgetExceededError if a hard budget for c is used up
+func (t *Tracker) check(now time.Time, c Counter) error {
+	for _, b := range t.opts.Budgets {
+		if b.Hard <= 0 || !b.matches(c) {
+			continue
+		}
+
+		used, err := t.Usage(b, now)
+		if err != nil {
+			return fmt.Errorf("error reading usage %w", err)
+		}
+
+		if used >= b.Hard {
+			return &BudgetExceededError{Budget: b, Used: used}
+		}
+	}
+
+	return nil
+}
+
+// record counts a billable call against each period, calling OnSoftLimit
+// for soft budgets it reaches
+func (t *Tracker) record(now time.Time, c Counter) error {
+	crossed, err := t.add(now, c)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range crossed {
+		t.opts.OnSoftLimit(r.budget, r.used)
+	}
+
+	return nil
+}
+
+// softLimit is a soft budget reached by a call
+type softLimit struct {
+	budget Budget
+	used   int64
+}
+
+// add counts a billable call, returning the soft budgets the count crossed.
+// The usage before and after is read under the same lock as the increment,
+// so each crossing is seen by exactly one call.
+func (t *Tracker) add(now time.Time, c Counter) ([]softLimit, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var soft []Budget
+	var prev []int64
+	if t.opts.OnSoftLimit != nil {
+		for _, b := range t.opts.Budgets {
+			if b.Soft <= 0 || !b.matches(c) {
+				continue
+			}
+
+			used, err := t.Usage(b, now)
+			if err != nil {
+				return nil, err
+			}
+
+			soft = append(soft, b)
+			prev = append(prev, used)
+		}
+	}
+
+	for _, p := range []Period{Daily, Monthly} {
+		pc := c
+		pc.Period = p.key(now)
+
+		if _, err := t.opts.Store.Add(pc, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	var crossed []softLimit
+	for i, b := range soft {
+		used, err := t.Usage(b, now)
+		if err != nil {
+			return nil, err
+		}
+
+		if prev[i] < b.Soft && used >= b.Soft {
+			crossed = append(crossed, softLimit{budget: b, used: used})
+		}
+	}
+
+	return crossed, nil
+}
+
+func sum(b Budget, counts map[Counter]int64) int64 {
+	var total int64
+	for c, n := range counts {
+		if b.matches(c) {
+			total += n
+		}
+	}
+
+	return total
+}
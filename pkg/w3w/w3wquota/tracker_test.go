@@ -0,0 +1,262 @@
+package w3wquota_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wquota"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	words  = w3w.Words{"filled", "count", "soap"}
+	coords = w3w.Coordinates{Lat: 51.520847, Lng: -0.195521}
+)
+
+func TestTracker(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		budgets []w3wquota.Budget
+		calls   int
+		tag     string
+
+		expectedErrs     int
+		expectedRequests int
+		expectedSoft     []int64
+	}{
+		{
+			desc:    "given calls within the budgets, every call made",
+			budgets: []w3wquota.Budget{{Period: w3wquota.Monthly, Hard: 10}},
+			calls:   5,
+
+			expectedRequests: 5,
+		},
+		{
+			desc:    "given calls beyond a hard budget, calls fail locally",
+			budgets: []w3wquota.Budget{{Period: w3wquota.Daily, Hard: 3}},
+			calls:   5,
+
+			expectedErrs:     2,
+			expectedRequests: 3,
+		},
+		{
+			desc:    "given a soft budget, OnSoftLimit called once it is reached",
+			budgets: []w3wquota.Budget{{Period: w3wquota.Monthly, Soft: 2, Hard: 4}},
+			calls:   5,
+
+			expectedErrs:     1,
+			expectedRequests: 4,
+			expectedSoft:     []int64{2},
+		},
+		{
+			desc:    "given a budget for another tag, every call made",
+			budgets: []w3wquota.Budget{{Period: w3wquota.Daily, Hard: 1, Tag: "batch"}},
+			calls:   3,
+			tag:     "web",
+
+			expectedRequests: 3,
+		},
+		{
+			desc:    "given a budget for the call's tag, calls beyond it fail",
+			budgets: []w3wquota.Budget{{Period: w3wquota.Daily, Hard: 1, Tag: "batch"}},
+			calls:   3,
+			tag:     "batch",
+
+			expectedErrs:     2,
+			expectedRequests: 1,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+			defer s.Close()
+
+			var soft []int64
+			tracker := w3wquota.NewTracker(w3wquota.TrackerOptions{
+				Budgets: tt.budgets,
+				OnSoftLimit: func(b w3wquota.Budget, used int64) {
+					soft = append(soft, used)
+				},
+			})
+
+			c, err := w3w.New("foobar", w3w.WithMiddleware(tracker.Middleware()))
+			assert.Nil(t, err)
+
+			ctx := w3wquota.ContextWithTag(context.Background(), tt.tag)
+
+			var errs int
+			for i := 0; i < tt.calls; i++ {
+				_, err := c.GetCoordinatesContext(ctx, words, w3w.CoordinateOptions{APIURL: s.URL})
+				if err != nil {
+					assert.True(t, errors.Is(err, w3wquota.ErrBudgetExceeded))
+
+					var budgetErr *w3wquota.BudgetExceededError
+					assert.True(t, errors.As(err, &budgetErr))
+					errs++
+				}
+			}
+
+			assert.Equal(t, tt.expectedErrs, errs)
+			assert.Len(t, s.Requests(), tt.expectedRequests)
+			assert.Equal(t, tt.expectedSoft, soft)
+		})
+	}
+}
+
+func TestTrackerSoftLimitConcurrent(t *testing.T) {
+	s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+	defer s.Close()
+
+	var (
+		mu   sync.Mutex
+		soft []int64
+	)
+	tracker := w3wquota.NewTracker(w3wquota.TrackerOptions{
+		Budgets: []w3wquota.Budget{{Period: w3wquota.Monthly, Soft: 10}},
+		OnSoftLimit: func(b w3wquota.Budget, used int64) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			soft = append(soft, used)
+		},
+	})
+
+	c, err := w3w.New("foobar", w3w.WithMiddleware(tracker.Middleware()))
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := c.GetCoordinates(words, w3w.CoordinateOptions{APIURL: s.URL})
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// exactly one of the calls crossed the soft budget
+	assert.Equal(t, []int64{10}, soft)
+}
+
+// failingStore is a Store which can't record counts
+type failingStore struct {
+	*w3wquota.MemoryStore
+}
+
+func (failingStore) Add(w3wquota.Counter, int64) (int64, error) {
+	return 0, errors.New("read-only file system")
+}
+
+func TestTrackerRecordError(t *testing.T) {
+	s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+	defer s.Close()
+
+	var errs []error
+	tracker := w3wquota.NewTracker(w3wquota.TrackerOptions{
+		Store: failingStore{w3wquota.NewMemoryStore()},
+		OnError: func(err error) {
+			errs = append(errs, err)
+		},
+	})
+
+	c, err := w3w.New("foobar", w3w.WithMiddleware(tracker.Middleware()))
+	assert.Nil(t, err)
+
+	// the call was billed, so its result is returned
+	res, err := c.GetCoordinates(words, w3w.CoordinateOptions{APIURL: s.URL})
+	assert.Nil(t, err)
+	assert.Equal(t, "filled.count.soap", res.Words)
+
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "error recording usage read-only file system")
+}
+
+func TestTrackerUsage(t *testing.T) {
+	s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+	defer s.Close()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "usage.json")
+	store, err := w3wquota.NewFileStore(path)
+	assert.Nil(t, err)
+
+	tracker := w3wquota.NewTracker(w3wquota.TrackerOptions{
+		Store: store,
+		Now:   func() time.Time { return now },
+	})
+
+	c, err := w3w.New("foobar", w3w.WithMiddleware(tracker.Middleware()))
+	assert.Nil(t, err)
+
+	c.GetCoordinates(words, w3w.CoordinateOptions{APIURL: s.URL})
+	c.GetWords(coords, w3w.WordOptions{APIURL: s.URL})
+	c.GetWords(coords, w3w.WordOptions{APIURL: s.URL, Key: "other"})
+
+	// error responses are not billable
+	s.InjectError("", w3wtest.BadWords, 1)
+	c.GetWords(coords, w3w.WordOptions{APIURL: s.URL})
+
+	now = now.AddDate(0, 0, 1)
+	c.GetWords(coords, w3w.WordOptions{APIURL: s.URL})
+
+	testCases := []struct {
+		desc   string
+		budget w3wquota.Budget
+		at     time.Time
+
+		expectedUsage int64
+	}{
+		{
+			desc:   "given a monthly budget, calls in the month counted",
+			budget: w3wquota.Budget{Period: w3wquota.Monthly},
+			at:     now,
+
+			expectedUsage: 4,
+		},
+		{
+			desc:   "given a daily budget, calls on the day counted",
+			budget: w3wquota.Budget{Period: w3wquota.Daily},
+			at:     now.AddDate(0, 0, -1),
+
+			expectedUsage: 3,
+		},
+		{
+			desc:   "given a route, calls to the route counted",
+			budget: w3wquota.Budget{Period: w3wquota.Monthly, Route: "convert-to-3wa"},
+			at:     now,
+
+			expectedUsage: 3,
+		},
+		{
+			desc:   "given a key, calls with the key counted",
+			budget: w3wquota.Budget{Period: w3wquota.Monthly, KeyID: w3w.KeyID("other")},
+			at:     now,
+
+			expectedUsage: 1,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			used, err := tracker.Usage(tt.budget, tt.at)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectedUsage, used)
+
+			// the counts are persisted
+			reloaded, err := w3wquota.NewFileStore(path)
+			assert.Nil(t, err)
+			used, err = w3wquota.NewTracker(w3wquota.TrackerOptions{Store: reloaded}).Usage(tt.budget, tt.at)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectedUsage, used)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package w3wtest
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+)
+
+const (
+	defaultSuggestions = 3
+	maxSuggestions     = 100
+)
+
+// autosuggestResponse defines the autosuggest response body
+type autosuggestResponse struct {
+	Suggestions []w3w.Suggestion `json:"suggestions"`
+}
+
+// autosuggest suggests the fixtures in the requested language whose words
+// start with the input and, for a grid server, the grid squares whose first
+// two words match and whose third starts with the rest of the input. Clip
+// parameters filter the candidates and a focus orders them by distance.
+func (s *Server) autosuggest(q url.Values) (interface{}, string) {
+	input := strings.ToLower(strings.TrimSpace(q.Get("input")))
+	if input == "" {
+		return nil, MissingInput
+	}
+
+	n := defaultSuggestions
+	if param := q.Get("n-results"); param != "" {
+		v, err := strconv.Atoi(param)
+		if err != nil || v < 1 || v > maxSuggestions {
+			return nil, BadNResults
+		}
+		n = v
+	}
+
+	language := q.Get("language")
+	if language == "" {
+		language = defaultLanguage
+	}
+
+	filter, code := newSuggestionFilter(q)
+	if code != "" {
+		return nil, code
+	}
+
+	var candidates []w3w.Result
+
+	s.mu.Lock()
+	for _, f := range s.fixtures {
+		if strings.EqualFold(f.Language, language) && strings.HasPrefix(strings.ToLower(f.Words), input) {
+			candidates = append(candidates, f)
+		}
+	}
+
+	if s.grid != nil && strings.EqualFold(language, defaultLanguage) {
+		candidates = append(candidates, s.grid.Complete(input)...)
+	}
+	s.mu.Unlock()
+
+	suggestions := []w3w.Suggestion{}
+	for _, c := range candidates {
+		if !filter.keep(c) {
+			continue
+		}
+
+		sug := w3w.Suggestion{
+			Country:      c.Country,
+			NearestPlace: c.NearestPlace,
+			Words:        c.Words,
+			Language:     c.Language,
+		}
+		if filter.focus != nil {
+			sug.DistanceToFocusKm = filter.focus.DistanceTo(c.Coordinates) / 1000
+		}
+
+		suggestions = append(suggestions, sug)
+	}
+
+	if filter.focus != nil {
+		sort.SliceStable(suggestions, func(i, j int) bool {
+			return suggestions[i].DistanceToFocusKm < suggestions[j].DistanceToFocusKm
+		})
+	}
+
+	if len(suggestions) > n {
+		suggestions = suggestions[:n]
+	}
+
+	for i := range suggestions {
+		suggestions[i].Rank = i + 1
+	}
+
+	return autosuggestResponse{Suggestions: suggestions}, ""
+}
+
+// suggestionFilter holds the autosuggest focus and clip parameters
+type suggestionFilter struct {
+	focus     *w3w.LatLng
+	countries []string
+	box       *w3w.Square
+	circle    *w3w.Circle
+}
+
+func newSuggestionFilter(q url.Values) (suggestionFilter, string) {
+	var f suggestionFilter
+
+	if param := q.Get("focus"); param != "" {
+		lat, lng, ok := parseCoordinates(param)
+		if !ok {
+			return f, BadFocus
+		}
+		f.focus = &w3w.LatLng{Lat: lat, Lng: lng}
+	}
+
+	if param := q.Get("clip-to-country"); param != "" {
+		f.countries = strings.Split(param, ",")
+		for _, c := range f.countries {
+			if len(c) != 2 {
+				return f, BadClipToCountry
+			}
+		}
+	}
+
+	if param := q.Get("clip-to-bounding-box"); param != "" {
+		box, ok := parseBoundingBox(param)
+		if !ok {
+			return f, BadClipToBoundingBox
+		}
+		f.box = &box
+	}
+
+	if param := q.Get("clip-to-circle"); param != "" {
+		i := strings.LastIndex(param, ",")
+		if i < 0 {
+			return f, BadClipToCircle
+		}
+
+		lat, lng, ok := parseCoordinates(param[:i])
+		if !ok {
+			return f, BadClipToCircle
+		}
+
+		km, err := strconv.ParseFloat(param[i+1:], 64)
+		if err != nil || !(km > 0) {
+			return f, BadClipToCircle
+		}
+
+		f.circle = &w3w.Circle{Center: w3w.LatLng{Lat: lat, Lng: lng}, RadiusKm: km}
+	}
+
+	return f, ""
+}
+
+func (f suggestionFilter) keep(res w3w.Result) bool {
+	if len(f.countries) > 0 {
+		var found bool
+		for _, c := range f.countries {
+			found = found || strings.EqualFold(c, res.Country)
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if f.box != nil && !f.box.Contains(res.Coordinates) {
+		return false
+	}
+
+	if f.circle != nil && f.circle.Center.DistanceTo(res.Coordinates) > f.circle.RadiusKm*1000 {
+		return false
+	}
+
+	return true
+}
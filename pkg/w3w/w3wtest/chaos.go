@@ -0,0 +1,241 @@
+package w3wtest
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Fault is a failure injected by a Chaos transport
+type Fault string
+
+// Faults injected by a Chaos transport
+const (
+	FaultDrop        Fault = "drop"
+	FaultRateLimit   Fault = "rate-limit"
+	FaultServerError Fault = "server-error"
+	FaultTruncate    Fault = "truncate"
+	FaultInvalidJSON Fault = "invalid-json"
+)
+
+// ErrConnectionDropped is returned by a Chaos transport for dropped requests
+var ErrConnectionDropped = errors.New("w3wtest: connection dropped")
+
+// invalidJSON is the body returned for FaultInvalidJSON
+const invalidJSON = `{"country":"GB","square":{`
+
+// ChaosConfig configures the faults injected by a Chaos transport. Rates are
+// the probability, from 0 to 1, of a request getting the fault. At most one
+// fault is injected per request, checked in the order of the fields below,
+// so the rates should add up to 1 or less.
+type ChaosConfig struct {
+	// Latency delays every request, plus a random duration up to Jitter
+	Latency time.Duration
+	Jitter  time.Duration
+
+	// DropRate fails requests with ErrConnectionDropped without sending them
+	DropRate float64
+	// RateLimitRate answers requests with a 429 Too Many Requests status
+	// and a Retry-After header of RetryAfter, without sending them
+	RateLimitRate float64
+	RetryAfter    time.Duration
+	// ServerErrorRate answers requests with a 500, 502, 503 or 504 status
+	// without sending them
+	ServerErrorRate float64
+	// TruncateRate cuts the response body short, its reader failing with
+	// io.ErrUnexpectedEOF
+	TruncateRate float64
+	// InvalidJSONRate replaces the response body with malformed JSON
+	InvalidJSONRate float64
+
+	// Seed seeds the random source so a run's faults can be reproduced
+	Seed int64
+}
+
+// Chaos is an http.RoundTripper which injects latency and faults into the
+// requests it passes on, for testing how callers cope with the API being
+// slow, flaky or rate limited:
+//
+//	chaos := w3wtest.NewChaos(nil, w3wtest.ChaosConfig{
+//		Latency:       50 * time.Millisecond,
+//		RateLimitRate: 0.1,
+//		DropRate:      0.05,
+//	})
+//
+//	c, _ := w3w.New(key, w3w.WithHTTPClient(&http.Client{Transport: chaos}))
+//
+// Status faults have plain text bodies, as a proxy in front of the API
+// would return, rather than the API's error JSON.
+type Chaos struct {
+	next http.RoundTripper
+	cfg  ChaosConfig
+
+	mu     sync.Mutex
+	rand   *rand.Rand
+	faults map[Fault]int
+}
+
+// NewChaos returns a Chaos transport sending requests with next, or
+// http.DefaultTransport when nil
+func NewChaos(next http.RoundTripper, cfg ChaosConfig) *Chaos {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &Chaos{
+		next:   next,
+		cfg:    cfg,
+		rand:   rand.New(rand.NewSource(cfg.Seed)),
+		faults: map[Fault]int{},
+	}
+}
+
+// Faults returns the number of times each fault has been injected
+func (c *Chaos) Faults() map[Fault]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	faults := make(map[Fault]int, len(c.faults))
+	for f, n := range c.faults {
+		faults[f] = n
+	}
+
+	return faults
+}
+
+// RoundTrip implements http.RoundTripper. Latency is cut short, returning
+// the context's error, when the request's context is done.
+func (c *Chaos) RoundTrip(req *http.Request) (*http.Response, error) {
+	delay, fault, status := c.roll()
+
+	if delay > 0 {
+		t := time.NewTimer(delay)
+		select {
+		case <-t.C:
+		case <-req.Context().Done():
+			t.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+
+	switch fault {
+	case FaultDrop:
+		return nil, ErrConnectionDropped
+	case FaultRateLimit, FaultServerError:
+		resp := textResponse(req, status)
+		if fault == FaultRateLimit && c.cfg.RetryAfter > 0 {
+			resp.Header.Set("Retry-After", strconv.Itoa(int(c.cfg.RetryAfter/time.Second)))
+		}
+
+		return resp, nil
+	}
+
+	resp, err := c.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch fault {
+	case FaultTruncate:
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Body = ioutil.NopCloser(io.MultiReader(
+			bytes.NewReader(body[:len(body)/2]),
+			errReader{io.ErrUnexpectedEOF},
+		))
+	case FaultInvalidJSON:
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader([]byte(invalidJSON)))
+		resp.ContentLength = int64(len(invalidJSON))
+		resp.Header.Del("Content-Length")
+	}
+
+	return resp, nil
+}
+
+// roll picks the delay and fault, if any, for a request along with the
+// status of status faults
+func (c *Chaos) roll() (time.Duration, Fault, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delay := c.cfg.Latency
+	if c.cfg.Jitter > 0 {
+		delay += time.Duration(c.rand.Int63n(int64(c.cfg.Jitter)))
+	}
+
+	rates := []struct {
+		fault Fault
+		rate  float64
+	}{
+		{FaultDrop, c.cfg.DropRate},
+		{FaultRateLimit, c.cfg.RateLimitRate},
+		{FaultServerError, c.cfg.ServerErrorRate},
+		{FaultTruncate, c.cfg.TruncateRate},
+		{FaultInvalidJSON, c.cfg.InvalidJSONRate},
+	}
+
+	var (
+		p   = c.rand.Float64()
+		sum float64
+	)
+	for _, r := range rates {
+		sum += r.rate
+		if p >= sum {
+			continue
+		}
+		c.faults[r.fault]++
+
+		switch r.fault {
+		case FaultRateLimit:
+			return delay, r.fault, http.StatusTooManyRequests
+		case FaultServerError:
+			return delay, r.fault, serverErrors[c.rand.Intn(len(serverErrors))]
+		}
+
+		return delay, r.fault, 0
+	}
+
+	return delay, "", 0
+}
+
+var serverErrors = []int{
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+func textResponse(req *http.Request, status int) *http.Response {
+	body := http.StatusText(status)
+
+	return &http.Response{
+		Status:        strconv.Itoa(status) + " " + body,
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"text/plain; charset=utf-8"}},
+		Body:          ioutil.NopCloser(bytes.NewReader([]byte(body))),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,134 @@
+package w3wtest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChaos(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		cfg     w3wtest.ChaosConfig
+		timeout time.Duration
+
+		expectedResult w3w.Result
+		expectedIs     error
+		expectedErr    string
+		expectedSent   int
+	}{
+		{
+			desc: "given no faults, result returned",
+			cfg:  w3wtest.ChaosConfig{Latency: time.Millisecond},
+
+			expectedResult: w3wtest.FilledCountSoap,
+			expectedSent:   1,
+		},
+		{
+			desc: "given a drop rate of 1, ErrConnectionDropped returned",
+			cfg:  w3wtest.ChaosConfig{DropRate: 1},
+
+			expectedIs: w3wtest.ErrConnectionDropped,
+		},
+		{
+			desc: "given a rate limit rate of 1, error JSON error returned",
+			cfg:  w3wtest.ChaosConfig{RateLimitRate: 1},
+
+			expectedErr: "invalid error JSON returned from API",
+		},
+		{
+			desc: "given a server error rate of 1, error JSON error returned",
+			cfg:  w3wtest.ChaosConfig{ServerErrorRate: 1},
+
+			expectedErr: "invalid error JSON returned from API",
+		},
+		{
+			desc: "given a truncate rate of 1, read error returned",
+			cfg:  w3wtest.ChaosConfig{TruncateRate: 1},
+
+			expectedIs:   io.ErrUnexpectedEOF,
+			expectedErr:  "error occurred reading response body",
+			expectedSent: 1,
+		},
+		{
+			desc: "given an invalid JSON rate of 1, JSON error returned",
+			cfg:  w3wtest.ChaosConfig{InvalidJSONRate: 1},
+
+			expectedErr:  "invalid JSON returned from API",
+			expectedSent: 1,
+		},
+		{
+			desc:    "given latency beyond the client timeout, timeout returned",
+			cfg:     w3wtest.ChaosConfig{Latency: time.Second},
+			timeout: 10 * time.Millisecond,
+
+			expectedIs: context.DeadlineExceeded,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+			defer s.Close()
+
+			c, err := w3w.New("foobar", w3w.WithHTTPClient(&http.Client{
+				Transport: w3wtest.NewChaos(nil, tt.cfg),
+				Timeout:   tt.timeout,
+			}))
+			assert.Nil(t, err)
+
+			res, err := c.GetCoordinates(w3w.Words{"filled", "count", "soap"}, w3w.CoordinateOptions{
+				APIURL: s.URL,
+			})
+
+			if tt.expectedIs != nil || tt.expectedErr != "" {
+				assert.NotNil(t, err)
+				if tt.expectedIs != nil {
+					assert.True(t, errors.Is(err, tt.expectedIs), "got %v", err)
+				}
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectedResult, res)
+			}
+
+			assert.Len(t, s.Requests(), tt.expectedSent)
+		})
+	}
+}
+
+func TestChaosRates(t *testing.T) {
+	s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+	defer s.Close()
+
+	chaos := w3wtest.NewChaos(nil, w3wtest.ChaosConfig{
+		RateLimitRate:   0.25,
+		ServerErrorRate: 0.25,
+		Seed:            1,
+	})
+	hc := &http.Client{Transport: chaos}
+
+	const requests = 400
+	statuses := map[int]int{}
+	for i := 0; i < requests; i++ {
+		resp, err := hc.Get(s.URL + "/convert-to-coordinates?key=foobar&words=filled.count.soap")
+		assert.Nil(t, err)
+		resp.Body.Close()
+
+		statuses[resp.StatusCode]++
+	}
+
+	faults := chaos.Faults()
+	assert.Equal(t, statuses[http.StatusTooManyRequests], faults[w3wtest.FaultRateLimit])
+	assert.Equal(t, requests-statuses[http.StatusOK], faults[w3wtest.FaultRateLimit]+faults[w3wtest.FaultServerError])
+	assert.InDelta(t, requests/4, faults[w3wtest.FaultRateLimit], requests/10)
+	assert.InDelta(t, requests/4, faults[w3wtest.FaultServerError], requests/10)
+	assert.Len(t, s.Requests(), statuses[http.StatusOK])
+}
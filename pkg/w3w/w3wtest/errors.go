@@ -0,0 +1,70 @@
+package w3wtest
+
+import (
+	"net/http"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+)
+
+// Error codes returned by the what3words API
+const (
+	BadWords           = "BadWords"
+	BadCoordinates     = "BadCoordinates"
+	BadLanguage        = "BadLanguage"
+	BadFormat          = "BadFormat"
+	MissingWords       = "MissingWords"
+	MissingCoordinates = "MissingCoordinates"
+	MissingKey         = "MissingKey"
+	InvalidKey         = "InvalidKey"
+	SuspendedKey       = "SuspendedKey"
+	QuotaExceeded      = "QuotaExceeded"
+
+	MissingInput         = "MissingInput"
+	BadNResults          = "BadNResults"
+	BadFocus             = "BadFocus"
+	BadClipToCountry     = "BadClipToCountry"
+	BadClipToBoundingBox = "BadClipToBoundingBox"
+	BadClipToCircle      = "BadClipToCircle"
+	MissingBoundingBox   = "MissingBoundingBox"
+	BadBoundingBox       = "BadBoundingBox"
+	BadBoundingBoxTooBig = "BadBoundingBoxTooBig"
+)
+
+// apiErrors holds the status and message the what3words API returns with
+// each error code
+var apiErrors = map[string]struct {
+	status  int
+	message string
+}{
+	BadWords:           {http.StatusBadRequest, "Invalid or non-existent 3 word address"},
+	BadCoordinates:     {http.StatusBadRequest, "coordinates must be two comma separated lat,lng coordinates"},
+	BadLanguage:        {http.StatusBadRequest, "Invalid language code"},
+	BadFormat:          {http.StatusBadRequest, "format must be json or geojson"},
+	MissingWords:       {http.StatusBadRequest, "words must be specified"},
+	MissingCoordinates: {http.StatusBadRequest, "coordinates must be specified"},
+	MissingKey:         {http.StatusUnauthorized, "Authentication failed; missing API key"},
+	InvalidKey:         {http.StatusUnauthorized, "Authentication failed; invalid API key"},
+	SuspendedKey:       {http.StatusUnauthorized, "Authentication failed; API key has been suspended"},
+	QuotaExceeded:      {http.StatusPaymentRequired, "Quota Exceeded. Please upgrade your usage plan, or contact support@what3words.com"},
+
+	MissingInput:         {http.StatusBadRequest, "input must be specified"},
+	BadNResults:          {http.StatusBadRequest, "n-results must be a positive integer no greater than 100"},
+	BadFocus:             {http.StatusBadRequest, "focus must be two comma separated lat,lng coordinates"},
+	BadClipToCountry:     {http.StatusBadRequest, "clip-to-country must be comma separated ISO 3166-1 alpha-2 country codes"},
+	BadClipToBoundingBox: {http.StatusBadRequest, "clip-to-bounding-box must be south_lat,west_lng,north_lat,east_lng"},
+	BadClipToCircle:      {http.StatusBadRequest, "clip-to-circle must be lat,lng,kilometres"},
+	MissingBoundingBox:   {http.StatusBadRequest, "bounding-box must be specified"},
+	BadBoundingBox:       {http.StatusBadRequest, "bounding-box must be south_lat,west_lng,north_lat,east_lng"},
+	BadBoundingBoxTooBig: {http.StatusBadRequest, "The diagonal of bounding-box may not be more than 4km"},
+}
+
+// NewError returns the error the what3words API responds with for code
+// along with its HTTP status. Unknown codes are returned as a bad request.
+func NewError(code string) (w3w.Error, int) {
+	e, ok := apiErrors[code]
+	if !ok {
+		return w3w.Error{Code: code, Message: code}, http.StatusBadRequest
+	}
+
+	return w3w.Error{Code: code, Message: e.message}, e.status
+}
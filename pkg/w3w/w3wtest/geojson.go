@@ -0,0 +1,61 @@
+package w3wtest
+
+import "github.com/jonnypillar/what3words/pkg/w3w"
+
+// FeatureCollection defines the API's geojson response body
+type FeatureCollection struct {
+	Features []Feature `json:"features"`
+	Type     string    `json:"type"`
+}
+
+// Feature defines a geojson feature, its bbox being the grid square's
+// southwest and northeast corners as [lng, lat, lng, lat]
+type Feature struct {
+	BBox       [4]float64 `json:"bbox"`
+	Geometry   Geometry   `json:"geometry"`
+	Type       string     `json:"type"`
+	Properties Properties `json:"properties"`
+}
+
+// Geometry defines a geojson point as [lng, lat]
+type Geometry struct {
+	Coordinates [2]float64 `json:"coordinates"`
+	Type        string     `json:"type"`
+}
+
+// Properties defines the non-geometric fields of a result
+type Properties struct {
+	Country      string `json:"country"`
+	NearestPlace string `json:"nearestPlace"`
+	Words        string `json:"words"`
+	Language     string `json:"language"`
+	Map          string `json:"map"`
+}
+
+func newFeatureCollection(res w3w.Result) FeatureCollection {
+	return FeatureCollection{
+		Type: "FeatureCollection",
+		Features: []Feature{
+			{
+				Type: "Feature",
+				BBox: [4]float64{
+					res.Square.Southwest.Lng,
+					res.Square.Southwest.Lat,
+					res.Square.Northeast.Lng,
+					res.Square.Northeast.Lat,
+				},
+				Geometry: Geometry{
+					Type:        "Point",
+					Coordinates: [2]float64{res.Coordinates.Lng, res.Coordinates.Lat},
+				},
+				Properties: Properties{
+					Country:      res.Country,
+					NearestPlace: res.NearestPlace,
+					Words:        res.Words,
+					Language:     res.Language,
+					Map:          res.Map,
+				},
+			},
+		},
+	}
+}
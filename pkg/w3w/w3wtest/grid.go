@@ -0,0 +1,320 @@
+package w3wtest
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+)
+
+const (
+	// gridCellMeters is the approximate size of a synthetic grid square
+	gridCellMeters = 3.0
+	metersPerDeg   = 111320.0
+
+	gridLatStep = gridCellMeters / metersPerDeg
+
+	// a cell is identified by its row and column, packed as row<<colBits | col
+	colBits = 24
+	colMask = 1<<colBits - 1
+
+	// the 48 bit cell ID is split between the three words
+	wordBits   = 16
+	wordMask   = 1<<wordBits - 1
+	halfBits   = 24
+	halfMask   = 1<<halfBits - 1
+	feistelRds = 4
+
+	gridCountry      = "ZZ"
+	gridNearestPlace = "Synthetic Grid"
+	gridMapURL       = "https://w3w.co/"
+)
+
+var (
+	gridRows = int64(math.Ceil(180 / gridLatStep))
+
+	// syllables are built from these letters so every byte maps to a unique
+	// three letter syllable
+	onsets = []byte("bcdfghjklmnprstv")
+	vowels = []byte("aeio")
+	codas  = []byte("nrst")
+)
+
+// Grid is a deterministic synthetic stand-in for the what3words grid.
+//
+// The Earth is divided into rows of roughly 3m in latitude, each row being
+// divided into as many roughly 3m columns as fit around its circumference.
+// Every square maps to a unique triple of made up words, shuffled by the
+// seed, so any coordinate converts to the same square and words for a given
+// seed and the words convert back to that square.
+type Grid struct {
+	keys [feistelRds]uint64
+}
+
+// NewGrid returns the grid for seed
+func NewGrid(seed int64) *Grid {
+	g := &Grid{}
+
+	state := uint64(seed)
+	for i := range g.keys {
+		state, g.keys[i] = splitMix64(state)
+	}
+
+	return g
+}
+
+// Result returns the square containing the coordinates. The coordinates
+// must be within the valid latitude and longitude ranges.
+func (g *Grid) Result(lat, lng float64) w3w.Result {
+	row := gridRow(lat)
+
+	cols := gridCols(row)
+	col := int64(math.Floor((lng + 180) / (360 / float64(cols))))
+	if col >= cols {
+		col = cols - 1
+	}
+
+	return g.result(row, col)
+}
+
+// Lookup returns the square for a 3 word address, reporting false when the
+// words do not belong to the grid
+func (g *Grid) Lookup(words string) (w3w.Result, bool) {
+	parts := strings.Split(strings.ToLower(words), ".")
+	if len(parts) != 3 {
+		return w3w.Result{}, false
+	}
+
+	var id uint64
+	for _, p := range parts {
+		n, ok := decodeWord(p)
+		if !ok {
+			return w3w.Result{}, false
+		}
+		id = id<<wordBits | uint64(n)
+	}
+
+	row, col, ok := g.cell(id)
+	if !ok {
+		return w3w.Result{}, false
+	}
+
+	return g.result(row, col), true
+}
+
+// Complete returns the squares whose first two words match input and whose
+// third word starts with the rest of it, such as "bakbak.bakbak.ba". At
+// least the first letter of the third word is needed.
+func (g *Grid) Complete(input string) []w3w.Result {
+	parts := strings.Split(strings.ToLower(input), ".")
+	if len(parts) != 3 || parts[2] == "" || len(parts[2]) > 6 {
+		return nil
+	}
+
+	var prefix uint64
+	for _, p := range parts[:2] {
+		n, ok := decodeWord(p)
+		if !ok {
+			return nil
+		}
+		prefix = prefix<<wordBits | uint64(n)
+	}
+
+	var results []w3w.Result
+	for n := 0; n <= wordMask; n++ {
+		if !strings.HasPrefix(encodeWord(uint16(n)), parts[2]) {
+			continue
+		}
+
+		row, col, ok := g.cell(prefix<<wordBits | uint64(n))
+		if ok {
+			results = append(results, g.result(row, col))
+		}
+	}
+
+	return results
+}
+
+// cell returns the row and column of the cell with the encrypted id,
+// reporting false when there is no such cell
+func (g *Grid) cell(id uint64) (int64, int64, bool) {
+	cell := g.decrypt(id)
+	row, col := int64(cell>>colBits), int64(cell&colMask)
+
+	if row >= gridRows || col >= gridCols(row) {
+		return 0, 0, false
+	}
+
+	return row, col, true
+}
+
+func (g *Grid) result(row, col int64) w3w.Result {
+	// the edges are computed as Lines computes them so squares share them
+	swLat := -90 + float64(row)*gridLatStep
+	neLat := math.Min(-90+float64(row+1)*gridLatStep, 90)
+
+	lngStep := 360 / float64(gridCols(row))
+	swLng := -180 + float64(col)*lngStep
+	neLng := math.Min(-180+float64(col+1)*lngStep, 180)
+
+	id := g.encrypt(uint64(row)<<colBits | uint64(col))
+	words := fmt.Sprintf("%s.%s.%s",
+		encodeWord(uint16(id>>(2*wordBits))),
+		encodeWord(uint16(id>>wordBits)),
+		encodeWord(uint16(id)),
+	)
+
+	return w3w.Result{
+		Country: gridCountry,
+		Square: w3w.Square{
+			Southwest: w3w.LatLng{Lat: swLat, Lng: swLng},
+			Northeast: w3w.LatLng{Lat: neLat, Lng: neLng},
+		},
+		NearestPlace: gridNearestPlace,
+		Coordinates: w3w.LatLng{
+			Lat: (swLat + neLat) / 2,
+			Lng: (swLng + neLng) / 2,
+		},
+		Words:    words,
+		Language: defaultLanguage,
+		Map:      gridMapURL + words,
+	}
+}
+
+// Lines returns the lines of the grid within box, which must not cross the
+// antimeridian. As the columns of neighbouring rows do not line up, the
+// lines between columns are returned a row at a time.
+func (g *Grid) Lines(box w3w.Square) []w3w.Line {
+	south, north := box.Southwest.Lat, box.Northeast.Lat
+	west, east := box.Southwest.Lng, box.Northeast.Lng
+
+	var lines []w3w.Line
+	for row := gridRow(south); row <= gridRow(north); row++ {
+		rowSouth := -90 + float64(row)*gridLatStep
+		rowNorth := math.Min(-90+float64(row+1)*gridLatStep, 90)
+
+		if rowSouth >= south {
+			lines = append(lines, w3w.Line{
+				Start: w3w.LatLng{Lat: rowSouth, Lng: west},
+				End:   w3w.LatLng{Lat: rowSouth, Lng: east},
+			})
+		}
+
+		from, to := math.Max(rowSouth, south), math.Min(rowNorth, north)
+		lngStep := 360 / float64(gridCols(row))
+
+		for col := math.Ceil((west + 180) / lngStep); ; col++ {
+			lng := -180 + col*lngStep
+			if lng > east {
+				break
+			}
+
+			lines = append(lines, w3w.Line{
+				Start: w3w.LatLng{Lat: from, Lng: lng},
+				End:   w3w.LatLng{Lat: to, Lng: lng},
+			})
+		}
+	}
+
+	return lines
+}
+
+// gridRow returns the row containing lat
+func gridRow(lat float64) int64 {
+	row := int64(math.Floor((lat + 90) / gridLatStep))
+	if row >= gridRows {
+		return gridRows - 1
+	}
+
+	return row
+}
+
+// gridCols returns the number of columns in row, keeping squares roughly
+// square by narrowing them towards the poles
+func gridCols(row int64) int64 {
+	lat := -90 + (float64(row)+0.5)*gridLatStep
+	cols := int64(math.Floor(360 * math.Cos(lat*math.Pi/180) / gridLatStep))
+
+	if cols < 1 {
+		return 1
+	}
+
+	return cols
+}
+
+// encrypt shuffles a 48 bit cell ID with a Feistel network keyed by the seed
+func (g *Grid) encrypt(v uint64) uint64 {
+	l, r := v>>halfBits&halfMask, v&halfMask
+	for _, k := range g.keys {
+		l, r = r, l^feistel(r, k)
+	}
+
+	return l<<halfBits | r
+}
+
+// decrypt reverses encrypt
+func (g *Grid) decrypt(v uint64) uint64 {
+	l, r := v>>halfBits&halfMask, v&halfMask
+	for i := len(g.keys) - 1; i >= 0; i-- {
+		l, r = r^feistel(l, g.keys[i]), l
+	}
+
+	return l<<halfBits | r
+}
+
+func feistel(v, key uint64) uint64 {
+	_, out := splitMix64(v ^ key)
+
+	return out & halfMask
+}
+
+func splitMix64(state uint64) (uint64, uint64) {
+	state += 0x9e3779b97f4a7c15
+
+	z := state
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+
+	return state, z ^ z>>31
+}
+
+// encodeWord returns the six letter word for n, a syllable per byte
+func encodeWord(n uint16) string {
+	return syllable(byte(n>>8)) + syllable(byte(n))
+}
+
+func syllable(b byte) string {
+	return string([]byte{onsets[b>>4], vowels[b>>2&3], codas[b&3]})
+}
+
+// decodeWord reverses encodeWord
+func decodeWord(w string) (uint16, bool) {
+	if len(w) != 6 {
+		return 0, false
+	}
+
+	hi, ok := parseSyllable(w[:3])
+	if !ok {
+		return 0, false
+	}
+
+	lo, ok := parseSyllable(w[3:])
+	if !ok {
+		return 0, false
+	}
+
+	return uint16(hi)<<8 | uint16(lo), true
+}
+
+func parseSyllable(s string) (byte, bool) {
+	o := strings.IndexByte(string(onsets), s[0])
+	v := strings.IndexByte(string(vowels), s[1])
+	c := strings.IndexByte(string(codas), s[2])
+
+	if o < 0 || v < 0 || c < 0 {
+		return 0, false
+	}
+
+	return byte(o<<4 | v<<2 | c), true
+}
@@ -0,0 +1,147 @@
+package w3wtest_test
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/stretchr/testify/assert"
+)
+
+const gridSeed = 42
+
+func TestGridRoundTrip(t *testing.T) {
+	s := w3wtest.NewGridServer(gridSeed)
+	defer s.Close()
+
+	c, err := w3w.New("foobar")
+	assert.Nil(t, err)
+
+	roundTrip := func(lat, lng float64) bool {
+		res, err := c.GetWords(w3w.Coordinates{Lat: lat, Lng: lng}, w3w.WordOptions{APIURL: s.URL})
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+
+		sq := res.Square
+		if lat < sq.Southwest.Lat || lat > sq.Northeast.Lat || lng < sq.Southwest.Lng || lng > sq.Northeast.Lng {
+			t.Logf("%f,%f is outside %+v", lat, lng, sq)
+			return false
+		}
+
+		back, err := c.GetCoordinates(words(res.Words), w3w.CoordinateOptions{APIURL: s.URL})
+		if err != nil || back != res {
+			t.Logf("%s converted back to %+v, %v", res.Words, back, err)
+			return false
+		}
+
+		centre, err := c.GetWords(w3w.Coordinates{Lat: res.Coordinates.Lat, Lng: res.Coordinates.Lng}, w3w.WordOptions{APIURL: s.URL})
+
+		return err == nil && centre.Words == res.Words
+	}
+
+	err = quick.Check(roundTrip, &quick.Config{
+		MaxCount: 500,
+		Rand:     rand.New(rand.NewSource(1)),
+		Values: func(args []reflect.Value, r *rand.Rand) {
+			args[0] = reflect.ValueOf(r.Float64()*180 - 90)
+			args[1] = reflect.ValueOf(r.Float64()*360 - 180)
+		},
+	})
+	assert.Nil(t, err)
+}
+
+func TestGridIsDeterministic(t *testing.T) {
+	coords := [][2]float64{
+		{51.520847, -0.195521},
+		{-33.856784, 151.215297},
+		{89.9999, 179.9999},
+		{-90, -180},
+	}
+
+	for _, c := range coords {
+		a := w3wtest.NewGrid(gridSeed).Result(c[0], c[1])
+		b := w3wtest.NewGrid(gridSeed).Result(c[0], c[1])
+		other := w3wtest.NewGrid(gridSeed+1).Result(c[0], c[1])
+
+		assert.Equal(t, a, b)
+		assert.Equal(t, a.Square, other.Square)
+		assert.NotEqual(t, a.Words, other.Words)
+
+		res, ok := w3wtest.NewGrid(gridSeed).Lookup(a.Words)
+		assert.True(t, ok)
+		assert.Equal(t, a, res)
+	}
+}
+
+func TestGridLookupUnknownWords(t *testing.T) {
+	g := w3wtest.NewGrid(gridSeed)
+
+	for _, words := range []string{"filled.count.soap", "one.two", "bazbaz.bazbaz"} {
+		_, ok := g.Lookup(words)
+		assert.False(t, ok, words)
+	}
+}
+
+func words(s string) w3w.Words {
+	parts := strings.SplitN(s, ".", 3)
+
+	return w3w.Words{parts[0], parts[1], parts[2]}
+}
+
+func TestGridComplete(t *testing.T) {
+	g := w3wtest.NewGrid(gridSeed)
+	res := g.Result(51.520847, -0.195521)
+
+	prefix := res.Words[:strings.LastIndex(res.Words, ".")+3]
+
+	completions := g.Complete(prefix)
+	assert.NotEmpty(t, completions)
+	assert.Contains(t, completions, res)
+
+	for _, c := range completions {
+		assert.True(t, strings.HasPrefix(c.Words, prefix), c.Words)
+
+		back, ok := g.Lookup(c.Words)
+		assert.True(t, ok)
+		assert.Equal(t, c, back)
+	}
+
+	for _, input := range []string{"", res.Words[:strings.LastIndex(res.Words, ".")+1], "filled.count.s", "one.two"} {
+		assert.Empty(t, g.Complete(input), input)
+	}
+}
+
+func TestGridLines(t *testing.T) {
+	g := w3wtest.NewGrid(gridSeed)
+	res := g.Result(51.520847, -0.195521)
+
+	// a box just around the square holds its edges and no others
+	lines := g.Lines(res.Square.Expand(0.5))
+
+	var horizontal, vertical []w3w.Line
+	for _, l := range lines {
+		if l.Start.Lat == l.End.Lat {
+			horizontal = append(horizontal, l)
+		} else {
+			vertical = append(vertical, l)
+		}
+	}
+
+	assert.Len(t, horizontal, 2)
+	assert.Equal(t, res.Square.Southwest.Lat, horizontal[0].Start.Lat)
+	assert.Equal(t, res.Square.Northeast.Lat, horizontal[1].Start.Lat)
+
+	var lngs []float64
+	for _, l := range vertical {
+		if l.Start.Lat <= res.Coordinates.Lat && l.End.Lat >= res.Coordinates.Lat {
+			lngs = append(lngs, l.Start.Lng)
+		}
+	}
+	assert.Equal(t, []float64{res.Square.Southwest.Lng, res.Square.Northeast.Lng}, lngs)
+}
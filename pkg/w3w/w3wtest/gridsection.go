@@ -0,0 +1,110 @@
+package w3wtest
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+)
+
+// maxGridSectionMeters is the longest bounding box diagonal the API accepts
+const maxGridSectionMeters = 4000
+
+// gridSectionResponse defines the grid-section response body
+type gridSectionResponse struct {
+	Lines []w3w.Line `json:"lines"`
+}
+
+// gridSection returns the edges of the fixture squares overlapping the
+// bounding box and, for a grid server, the grid's lines within it
+func (s *Server) gridSection(q url.Values) (interface{}, string) {
+	param := q.Get("bounding-box")
+	if param == "" {
+		return nil, MissingBoundingBox
+	}
+
+	box, ok := parseBoundingBox(param)
+	if !ok {
+		return nil, BadBoundingBox
+	}
+
+	if box.Southwest.DistanceTo(box.Northeast) > maxGridSectionMeters {
+		return nil, BadBoundingBoxTooBig
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lines := []w3w.Line{}
+	seen := map[w3w.Square]bool{}
+
+	for _, part := range splitAntimeridian(box) {
+		for _, f := range s.fixtures {
+			if seen[f.Square] || !overlaps(f.Square, part) {
+				continue
+			}
+			seen[f.Square] = true
+
+			lines = append(lines, edges(f.Square)...)
+		}
+
+		if s.grid != nil {
+			lines = append(lines, s.grid.Lines(part)...)
+		}
+	}
+
+	return gridSectionResponse{Lines: lines}, ""
+}
+
+// parseBoundingBox parses a south_lat,west_lng,north_lat,east_lng box
+func parseBoundingBox(param string) (w3w.Square, bool) {
+	parts := strings.Split(param, ",")
+	if len(parts) != 4 {
+		return w3w.Square{}, false
+	}
+
+	sLat, sLng, ok := parseCoordinates(parts[0] + "," + parts[1])
+	if !ok {
+		return w3w.Square{}, false
+	}
+
+	nLat, nLng, ok := parseCoordinates(parts[2] + "," + parts[3])
+	if !ok || sLat > nLat {
+		return w3w.Square{}, false
+	}
+
+	return w3w.Square{
+		Southwest: w3w.LatLng{Lat: sLat, Lng: sLng},
+		Northeast: w3w.LatLng{Lat: nLat, Lng: nLng},
+	}, true
+}
+
+// splitAntimeridian splits a box crossing the antimeridian into the boxes
+// either side of it
+func splitAntimeridian(box w3w.Square) []w3w.Square {
+	if box.Southwest.Lng <= box.Northeast.Lng {
+		return []w3w.Square{box}
+	}
+
+	east, west := box, box
+	east.Northeast.Lng = 180
+	west.Southwest.Lng = -180
+
+	return []w3w.Square{east, west}
+}
+
+func overlaps(a, b w3w.Square) bool {
+	return a.Southwest.Lat <= b.Northeast.Lat && a.Northeast.Lat >= b.Southwest.Lat &&
+		a.Southwest.Lng <= b.Northeast.Lng && a.Northeast.Lng >= b.Southwest.Lng
+}
+
+func edges(sq w3w.Square) []w3w.Line {
+	corners := sq.Polygon()
+
+	lines := make([]w3w.Line, 0, 4)
+	for i := 0; i < 4; i++ {
+		lines = append(lines, w3w.Line{Start: corners[i], End: corners[i+1]})
+	}
+
+	return lines
+}
@@ -0,0 +1,19 @@
+package w3wtest
+
+import "github.com/jonnypillar/what3words/pkg/w3w"
+
+// Languages are returned by available-languages
+var Languages = []w3w.Language{
+	{Code: "de", Name: "German", NativeName: "Deutsch"},
+	{Code: "en", Name: "English", NativeName: "English"},
+	{Code: "es", Name: "Spanish", NativeName: "Español"},
+	{Code: "fr", Name: "French", NativeName: "Français"},
+	{Code: "it", Name: "Italian", NativeName: "Italiano"},
+	{Code: "ja", Name: "Japanese", NativeName: "日本語"},
+	{Code: "pt", Name: "Portuguese", NativeName: "Português"},
+}
+
+// languagesResponse defines the available-languages response body
+type languagesResponse struct {
+	Languages []w3w.Language `json:"languages"`
+}
@@ -0,0 +1,292 @@
+package w3wtest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	paramKey         = "key"
+	paramWords       = "words"
+	paramCoordinates = "coordinates"
+	paramFormat      = "format"
+	paramLanguage    = "language"
+)
+
+// Body encodings of an Interaction
+const (
+	// EncodingJSON bodies are stored as JSON, the default when empty
+	EncodingJSON = "json"
+	// EncodingBase64 bodies which aren't JSON, such as HTML error pages or
+	// empty bodies, are stored as a base64 string of their bytes
+	EncodingBase64 = "base64"
+)
+
+// Interaction is a recorded API request and its response. The request is
+// stored as its route and normalised parameters, without the API key.
+type Interaction struct {
+	Route    string            `json:"route"`
+	Params   map[string]string `json:"params"`
+	Status   int               `json:"status"`
+	Encoding string            `json:"encoding,omitempty"`
+	Body     json.RawMessage   `json:"body"`
+}
+
+// Cassette is the fixture file format written by a Recorder and read by a
+// Replayer
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper which passes requests on to the real API
+// and records each interaction so it can be saved as a fixture file:
+//
+//	rec := w3wtest.NewRecorder("testdata/coordinates.json", nil)
+//	defer rec.Save()
+//
+//	c, _ := w3w.New(key, w3w.WithHTTPClient(&http.Client{Transport: rec}))
+type Recorder struct {
+	path string
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a Recorder saving to path, sending requests with next
+// or http.DefaultTransport when nil
+func NewRecorder(path string, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &Recorder{
+		path: path,
+		next: next,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	route, params := normalise(req.URL)
+	encoding, encoded := encodeBody(body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Route:    route,
+		Params:   params,
+		Status:   resp.StatusCode,
+		Encoding: encoding,
+		Body:     encoded,
+	})
+
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the Recorder's path, creating
+// its directory if needed
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("error creating fixture directory %w", err)
+	}
+
+	return ioutil.WriteFile(r.path, append(b, '\n'), 0644)
+}
+
+// Replayer is an http.RoundTripper which answers requests from a fixture
+// file written by a Recorder, without making network calls. Requests are
+// matched on their route and normalised parameters, ignoring the API key.
+type Replayer struct {
+	interactions []Interaction
+}
+
+// NewReplayer loads the fixture file at path
+func NewReplayer(path string) (*Replayer, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixture file %w", err)
+	}
+
+	var c Cassette
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("invalid fixture file %s: %w", path, err)
+	}
+
+	return &Replayer{
+		interactions: c.Interactions,
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper, returning an error for requests
+// which were not recorded
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	route, params := normalise(req.URL)
+
+	for _, in := range r.interactions {
+		if in.Route != route || !equalParams(in.Params, params) {
+			continue
+		}
+
+		body, contentType, err := decodeBody(in)
+		if err != nil {
+			return nil, err
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Status, http.StatusText(in.Status)),
+			StatusCode:    in.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{contentType}},
+			Body:          ioutil.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction for %s %s", route, encodeParams(params))
+}
+
+// normalise returns the route of u and its parameters without the API key,
+// lower casing words and languages, formatting coordinates consistently and
+// making the default json format explicit
+func normalise(u *url.URL) (string, map[string]string) {
+	route := u.Path[strings.LastIndex(u.Path, "/")+1:]
+
+	params := map[string]string{}
+	for k, v := range u.Query() {
+		if k == paramKey || len(v) == 0 {
+			continue
+		}
+
+		val := v[0]
+		switch k {
+		case paramWords, paramLanguage:
+			val = strings.ToLower(val)
+		case paramCoordinates:
+			val = normaliseCoordinates(val)
+		}
+		params[k] = val
+	}
+
+	if _, ok := params[paramFormat]; !ok {
+		params[paramFormat] = formatJSON
+	}
+
+	return route, params
+}
+
+func normaliseCoordinates(v string) string {
+	parts := strings.Split(v, ",")
+	if len(parts) != 2 {
+		return v
+	}
+
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return v
+		}
+		parts[i] = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	return strings.Join(parts, ",")
+}
+
+func equalParams(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+func encodeParams(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + params[k]
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+// encodeBody keeps JSON bodies readable in the fixture file, storing
+// anything else as base64 so it is replayed byte for byte
+func encodeBody(body []byte) (string, json.RawMessage) {
+	var buf bytes.Buffer
+	if json.Valid(body) && json.Compact(&buf, body) == nil {
+		return EncodingJSON, buf.Bytes()
+	}
+
+	b, _ := json.Marshal(base64.StdEncoding.EncodeToString(body))
+
+	return EncodingBase64, b
+}
+
+// decodeBody returns the recorded body of in and its content type
+func decodeBody(in Interaction) ([]byte, string, error) {
+	switch in.Encoding {
+	case EncodingJSON, "":
+		return in.Body, "application/json", nil
+	case EncodingBase64:
+		var s string
+		if err := json.Unmarshal(in.Body, &s); err != nil {
+			return nil, "", fmt.Errorf("invalid base64 body for %s: %w", in.Route, err)
+		}
+
+		body, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid base64 body for %s: %w", in.Route, err)
+		}
+
+		return body, http.DetectContentType(body), nil
+	}
+
+	return nil, "", fmt.Errorf("unsupported body encoding %q for %s", in.Encoding, in.Route)
+}
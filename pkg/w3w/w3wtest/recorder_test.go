@@ -0,0 +1,149 @@
+package w3wtest_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "fixture.json")
+
+	s := w3wtest.NewServer(w3wtest.FilledCountSoap, filledCountSoapFR)
+	defer s.Close()
+
+	rec := w3wtest.NewRecorder(path, nil)
+	c, err := w3w.New("secret-key", w3w.WithHTTPClient(&http.Client{Transport: rec}))
+	assert.Nil(t, err)
+
+	_, err = c.GetCoordinates(w3w.Words{"filled", "count", "soap"}, w3w.CoordinateOptions{APIURL: s.URL})
+	assert.Nil(t, err)
+	_, err = c.GetWords(w3w.Coordinates{Lat: 51.520847, Lng: -0.195521}, w3w.WordOptions{APIURL: s.URL, Language: "fr"})
+	assert.Nil(t, err)
+	_, err = c.GetCoordinates(w3w.Words{"one", "two", "three"}, w3w.CoordinateOptions{APIURL: s.URL})
+	assert.NotNil(t, err)
+
+	assert.Nil(t, rec.Save())
+
+	b, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(b), "secret-key")
+
+	rep, err := w3wtest.NewReplayer(path)
+	assert.Nil(t, err)
+
+	c, err = w3w.New("another-key", w3w.WithHTTPClient(&http.Client{Transport: rep}))
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		desc string
+		call func() (w3w.Result, error)
+
+		expectedResult w3w.Result
+		expectedErr    error
+	}{
+		{
+			desc: "given recorded words in another case, recorded result returned",
+			call: func() (w3w.Result, error) {
+				return c.GetCoordinates(w3w.Words{"FILLED", "count", "soap"}, w3w.CoordinateOptions{APIURL: "https://example.com/v3"})
+			},
+
+			expectedResult: w3wtest.FilledCountSoap,
+		},
+		{
+			desc: "given recorded coordinates and language, recorded result returned",
+			call: func() (w3w.Result, error) {
+				return c.GetWords(w3w.Coordinates{Lat: 51.520847, Lng: -0.195521}, w3w.WordOptions{Language: "FR"})
+			},
+
+			expectedResult: filledCountSoapFR,
+		},
+		{
+			desc: "given a recorded error response, recorded error returned",
+			call: func() (w3w.Result, error) {
+				return c.GetCoordinates(w3w.Words{"one", "two", "three"}, w3w.CoordinateOptions{})
+			},
+
+			expectedErr: w3w.Error{Code: "BadWords", Message: "Invalid or non-existent 3 word address"},
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			res, err := tt.call()
+
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectedResult, res)
+			}
+		})
+	}
+
+	t.Run("given an unrecorded request, error returned", func(t *testing.T) {
+		_, err := c.GetWords(w3w.Coordinates{Lat: 51.520847, Lng: -0.195521}, w3w.WordOptions{Language: "de"})
+
+		assert.NotNil(t, err)
+		assert.Len(t, s.Requests(), 3)
+	})
+}
+
+func TestRecordReplayRawBodies(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.json")
+
+	bodies := map[string]string{
+		"1,2": "<html><body>502 Bad Gateway</body></html>",
+		"3,4": "",
+		"5,6": `{"words": "filled.count.soap"}`,
+	}
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(bodies[r.URL.Query().Get("coordinates")]))
+	}))
+	defer s.Close()
+
+	get := func(c *http.Client, coords string) (int, string) {
+		resp, err := c.Get(s.URL + "/v3/convert-to-3wa?key=secret-key&coordinates=" + coords)
+		assert.Nil(t, err)
+		defer resp.Body.Close()
+
+		b, err := ioutil.ReadAll(resp.Body)
+		assert.Nil(t, err)
+
+		return resp.StatusCode, string(b)
+	}
+
+	rec := w3wtest.NewRecorder(path, nil)
+	for coords := range bodies {
+		get(&http.Client{Transport: rec}, coords)
+	}
+	assert.Nil(t, rec.Save())
+
+	rep, err := w3wtest.NewReplayer(path)
+	assert.Nil(t, err)
+
+	for coords, body := range bodies {
+		status, replayed := get(&http.Client{Transport: rep}, coords)
+
+		assert.Equal(t, http.StatusBadGateway, status, coords)
+		if coords == "5,6" {
+			assert.JSONEq(t, body, replayed)
+		} else {
+			assert.Equal(t, body, replayed, coords)
+		}
+	}
+}
+
+func TestNewReplayer(t *testing.T) {
+	_, err := w3wtest.NewReplayer(filepath.Join(t.TempDir(), "missing.json"))
+
+	assert.NotNil(t, err)
+}
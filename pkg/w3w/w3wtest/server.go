@@ -0,0 +1,369 @@
+// Package w3wtest provides a fake what3words API for testing code which uses
+// the w3w package.
+//
+// A Server answers requests from a table of fixture results, and optionally
+// a deterministic synthetic grid, returning the same response shapes as the
+// real API in both its json and geojson formats. Fixtures and the grid also
+// answer autosuggest and grid-section, and available-languages returns
+// Languages. It can be told to fail requests with any of the API's error
+// codes:
+//
+//	s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+//	defer s.Close()
+//
+//	c, _ := w3w.New("any-key")
+//	res, err := c.GetCoordinates(w3w.Words{"filled", "count", "soap"}, w3w.CoordinateOptions{
+//		APIURL: s.URL,
+//	})
+//
+//	s.InjectError(w3wtest.RouteConvertToCoordinates, w3wtest.QuotaExceeded, 1)
+package w3wtest
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+)
+
+// Routes served by the fake API
+const (
+	RouteConvertToCoordinates = "convert-to-coordinates"
+	RouteConvertToWords       = "convert-to-3wa"
+	RouteAutoSuggest          = "autosuggest"
+	RouteGridSection          = "grid-section"
+	RouteAvailableLanguages   = "available-languages"
+)
+
+const (
+	formatJSON    = "json"
+	formatGeoJSON = "geojson"
+
+	defaultLanguage = "en"
+)
+
+// FilledCountSoap is the fixture for ///filled.count.soap in London
+var FilledCountSoap = w3w.Result{
+	Country: "GB",
+	Square: w3w.Square{
+		Southwest: w3w.LatLng{
+			Lng: -0.195543,
+			Lat: 51.520833,
+		},
+		Northeast: w3w.LatLng{
+			Lng: -0.195499,
+			Lat: 51.52086,
+		},
+	},
+	NearestPlace: "Bayswater, London",
+	Coordinates: w3w.LatLng{
+		Lng: -0.195521,
+		Lat: 51.520847,
+	},
+	Words:    "filled.count.soap",
+	Language: "en",
+	Map:      "https://w3w.co/filled.count.soap",
+}
+
+// Server is a fake what3words API listening on a local address, its URL
+// being used as the client's APIURL option
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	key      string
+	grid     *Grid
+	fixtures []w3w.Result
+	errors   []*injectedError
+	requests []*url.URL
+}
+
+type injectedError struct {
+	route     string
+	code      string
+	remaining int
+}
+
+// NewServer starts a fake API answering from the given fixtures
+func NewServer(fixtures ...w3w.Result) *Server {
+	s := &Server{}
+	s.Add(fixtures...)
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// NewGridServer starts a fake API answering from the synthetic grid for seed,
+// so every valid coordinate converts to a square and its words convert back.
+// Fixtures take precedence over the grid.
+func NewGridServer(seed int64, fixtures ...w3w.Result) *Server {
+	s := NewServer(fixtures...)
+
+	s.mu.Lock()
+	s.grid = NewGrid(seed)
+	s.mu.Unlock()
+
+	return s
+}
+
+// Add adds fixtures to the table. A fixture is returned by
+// convert-to-coordinates for its words and by convert-to-3wa for any
+// coordinates within its square, in its language. It is suggested by
+// autosuggest for the start of its words and its square's edges are
+// returned by grid-section.
+func (s *Server) Add(fixtures ...w3w.Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fixtures = append(s.fixtures, fixtures...)
+}
+
+// RequireKey makes the server reject requests which do not use key with
+// MissingKey or InvalidKey errors. By default any key is accepted.
+func (s *Server) RequireKey(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.key = key
+}
+
+// InjectError makes the next times requests to route fail with the error
+// code, such as BadWords or QuotaExceeded. An empty route matches every
+// route and times of 0 or less fails requests until ClearErrors is called.
+// Injected errors are checked in the order they were added.
+func (s *Server) InjectError(route, code string, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errors = append(s.errors, &injectedError{
+		route:     route,
+		code:      code,
+		remaining: times,
+	})
+}
+
+// ClearErrors removes all injected errors
+func (s *Server) ClearErrors() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errors = nil
+}
+
+// Requests returns the URLs of the requests the server has received
+func (s *Server) Requests() []*url.URL {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reqs := make([]*url.URL, len(s.requests))
+	copy(reqs, s.requests)
+
+	return reqs
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	u := *r.URL
+
+	s.mu.Lock()
+	s.requests = append(s.requests, &u)
+	s.mu.Unlock()
+
+	route := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	q := r.URL.Query()
+
+	if code := s.injectedError(route); code != "" {
+		writeError(w, code)
+		return
+	}
+
+	if code := s.checkKey(q.Get("key")); code != "" {
+		writeError(w, code)
+		return
+	}
+
+	format := q.Get("format")
+	if format != "" && format != formatJSON && format != formatGeoJSON {
+		writeError(w, BadFormat)
+		return
+	}
+
+	var (
+		body interface{}
+		code string
+	)
+	switch route {
+	case RouteConvertToCoordinates:
+		body, code = s.convertToCoordinates(q)
+	case RouteConvertToWords:
+		body, code = s.convertToWords(q)
+	case RouteAutoSuggest:
+		body, code = s.autosuggest(q)
+	case RouteGridSection:
+		body, code = s.gridSection(q)
+	case RouteAvailableLanguages:
+		body = languagesResponse{Languages: Languages}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	if code != "" {
+		writeError(w, code)
+		return
+	}
+
+	if res, ok := body.(w3w.Result); ok && format == formatGeoJSON {
+		body = newFeatureCollection(res)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+func (s *Server) convertToCoordinates(q url.Values) (w3w.Result, string) {
+	words := q.Get("words")
+	if words == "" {
+		return w3w.Result{}, MissingWords
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, f := range s.fixtures {
+		if strings.EqualFold(f.Words, words) {
+			return f, ""
+		}
+	}
+
+	if s.grid != nil {
+		if res, ok := s.grid.Lookup(words); ok {
+			return res, ""
+		}
+	}
+
+	return w3w.Result{}, BadWords
+}
+
+func (s *Server) convertToWords(q url.Values) (w3w.Result, string) {
+	param := q.Get("coordinates")
+	if param == "" {
+		return w3w.Result{}, MissingCoordinates
+	}
+
+	lat, lng, ok := parseCoordinates(param)
+	if !ok {
+		return w3w.Result{}, BadCoordinates
+	}
+
+	language := q.Get("language")
+	if language == "" {
+		language = defaultLanguage
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var found bool
+	for _, f := range s.fixtures {
+		if !contains(f.Square, lat, lng) {
+			continue
+		}
+		found = true
+
+		if strings.EqualFold(f.Language, language) {
+			return f, ""
+		}
+	}
+
+	if s.grid != nil && strings.EqualFold(language, defaultLanguage) {
+		return s.grid.Result(lat, lng), ""
+	}
+
+	// the point is known but not in the requested language
+	if found || s.grid != nil {
+		return w3w.Result{}, BadLanguage
+	}
+
+	return w3w.Result{}, BadCoordinates
+}
+
+// injectedError returns the code of the first injected error matching route,
+// counting down its remaining uses
+func (s *Server) injectedError(route string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, e := range s.errors {
+		if e.route != "" && e.route != route {
+			continue
+		}
+
+		if e.remaining > 0 {
+			e.remaining--
+			if e.remaining == 0 {
+				s.errors = append(s.errors[:i], s.errors[i+1:]...)
+			}
+		}
+
+		return e.code
+	}
+
+	return ""
+}
+
+func (s *Server) checkKey(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case key == "":
+		return MissingKey
+	case s.key != "" && key != s.key:
+		return InvalidKey
+	}
+
+	return ""
+}
+
+// errorResponse defines the API's error body
+type errorResponse struct {
+	Error w3w.Error `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, code string) {
+	err, status := NewError(code)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Error: err})
+}
+
+func parseCoordinates(param string) (float64, float64, bool) {
+	parts := strings.Split(param, ",")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+
+	lat, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || math.IsNaN(lat) || lat < -90 || lat > 90 {
+		return 0, 0, false
+	}
+
+	lng, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil || math.IsNaN(lng) || lng < -180 || lng > 180 {
+		return 0, 0, false
+	}
+
+	return lat, lng, true
+}
+
+func contains(sq w3w.Square, lat, lng float64) bool {
+	return lat >= sq.Southwest.Lat && lat <= sq.Northeast.Lat &&
+		lng >= sq.Southwest.Lng && lng <= sq.Northeast.Lng
+}
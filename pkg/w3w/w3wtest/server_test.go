@@ -0,0 +1,303 @@
+package w3wtest_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var filledCountSoapFR = w3w.Result{
+	Country:      "GB",
+	Square:       w3wtest.FilledCountSoap.Square,
+	NearestPlace: "Bayswater, Grand Londres",
+	Coordinates:  w3wtest.FilledCountSoap.Coordinates,
+	Words:        "conduite.richissime.empâter",
+	Language:     "fr",
+	Map:          "https://w3w.co/conduite.richissime.emp%C3%A2ter",
+}
+
+func TestServerGetCoordinates(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		words  w3w.Words
+		inject func(s *w3wtest.Server)
+
+		expectedResult w3w.Result
+		expectedErr    error
+	}{
+		{
+			desc:  "given words in the fixture table, fixture returned",
+			words: w3w.Words{"Filled", "count", "soap"},
+
+			expectedResult: w3wtest.FilledCountSoap,
+		},
+		{
+			desc:  "given words not in the fixture table, BadWords returned",
+			words: w3w.Words{"one", "two", "three"},
+
+			expectedErr: w3w.Error{Code: "BadWords", Message: "Invalid or non-existent 3 word address"},
+		},
+		{
+			desc:  "given an injected error for the route, injected error returned",
+			words: w3w.Words{"filled", "count", "soap"},
+			inject: func(s *w3wtest.Server) {
+				s.InjectError(w3wtest.RouteConvertToCoordinates, w3wtest.QuotaExceeded, 1)
+			},
+
+			expectedErr: w3w.Error{Code: "QuotaExceeded", Message: "Quota Exceeded. Please upgrade your usage plan, or contact support@what3words.com"},
+		},
+		{
+			desc:  "given an injected error for another route, fixture returned",
+			words: w3w.Words{"filled", "count", "soap"},
+			inject: func(s *w3wtest.Server) {
+				s.InjectError(w3wtest.RouteConvertToWords, w3wtest.BadCoordinates, 0)
+			},
+
+			expectedResult: w3wtest.FilledCountSoap,
+		},
+		{
+			desc:  "given a required key that is not used, InvalidKey returned",
+			words: w3w.Words{"filled", "count", "soap"},
+			inject: func(s *w3wtest.Server) {
+				s.RequireKey("another-key")
+			},
+
+			expectedErr: w3w.Error{Code: "InvalidKey", Message: "Authentication failed; invalid API key"},
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+			defer s.Close()
+
+			if tt.inject != nil {
+				tt.inject(s)
+			}
+
+			c, err := w3w.New("foobar")
+			assert.Nil(t, err)
+
+			res, err := c.GetCoordinates(tt.words, w3w.CoordinateOptions{
+				APIURL: s.URL,
+			})
+
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectedResult, res)
+			}
+		})
+	}
+}
+
+func TestServerGetWords(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		coords   w3w.Coordinates
+		language string
+
+		expectedResult w3w.Result
+		expectedErr    error
+	}{
+		{
+			desc:   "given coordinates within a fixture's square, fixture returned",
+			coords: w3w.Coordinates{Lat: 51.52085, Lng: -0.1955},
+
+			expectedResult: w3wtest.FilledCountSoap,
+		},
+		{
+			desc:     "given a language, fixture in that language returned",
+			coords:   w3w.Coordinates{Lat: 51.520847, Lng: -0.195521},
+			language: "fr",
+
+			expectedResult: filledCountSoapFR,
+		},
+		{
+			desc:     "given a language without a fixture, BadLanguage returned",
+			coords:   w3w.Coordinates{Lat: 51.520847, Lng: -0.195521},
+			language: "de",
+
+			expectedErr: w3w.Error{Code: "BadLanguage", Message: "Invalid language code"},
+		},
+		{
+			desc:   "given coordinates outside every fixture, BadCoordinates returned",
+			coords: w3w.Coordinates{Lat: 10, Lng: 10},
+
+			expectedErr: w3w.Error{Code: "BadCoordinates", Message: "coordinates must be two comma separated lat,lng coordinates"},
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := w3wtest.NewServer(w3wtest.FilledCountSoap, filledCountSoapFR)
+			defer s.Close()
+
+			c, err := w3w.New("foobar")
+			assert.Nil(t, err)
+
+			res, err := c.GetWords(tt.coords, w3w.WordOptions{
+				APIURL:   s.URL,
+				Language: tt.language,
+			})
+
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectedResult, res)
+			}
+		})
+	}
+}
+
+func TestServerGeoJSON(t *testing.T) {
+	s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+	defer s.Close()
+
+	resp, err := http.Get(s.URL + "/convert-to-coordinates?key=foobar&format=geojson&words=filled.count.soap")
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+
+	var fc w3wtest.FeatureCollection
+	assert.Nil(t, json.NewDecoder(resp.Body).Decode(&fc))
+
+	assert.Equal(t, w3wtest.FeatureCollection{
+		Type: "FeatureCollection",
+		Features: []w3wtest.Feature{
+			{
+				Type: "Feature",
+				BBox: [4]float64{-0.195543, 51.520833, -0.195499, 51.52086},
+				Geometry: w3wtest.Geometry{
+					Type:        "Point",
+					Coordinates: [2]float64{-0.195521, 51.520847},
+				},
+				Properties: w3wtest.Properties{
+					Country:      "GB",
+					NearestPlace: "Bayswater, London",
+					Words:        "filled.count.soap",
+					Language:     "en",
+					Map:          "https://w3w.co/filled.count.soap",
+				},
+			},
+		},
+	}, fc)
+}
+
+func TestServerInjectedErrorsExpire(t *testing.T) {
+	s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+	defer s.Close()
+
+	s.InjectError("", w3wtest.BadWords, 2)
+
+	c, err := w3w.New("foobar")
+	assert.Nil(t, err)
+
+	var codes []string
+	for i := 0; i < 3; i++ {
+		_, err := c.GetCoordinates(w3w.Words{"filled", "count", "soap"}, w3w.CoordinateOptions{
+			APIURL: s.URL,
+		})
+
+		var w3wErr w3w.Error
+		errors.As(err, &w3wErr)
+		codes = append(codes, w3wErr.Code)
+	}
+
+	assert.Equal(t, []string{"BadWords", "BadWords", ""}, codes)
+	assert.Len(t, s.Requests(), 3)
+}
+
+func TestServerParamErrors(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		query string
+
+		expectedCode string
+	}{
+		{
+			desc:  "given autosuggest without input, MissingInput returned",
+			query: "/autosuggest?key=foobar",
+
+			expectedCode: w3wtest.MissingInput,
+		},
+		{
+			desc:  "given too many autosuggest results, BadNResults returned",
+			query: "/autosuggest?key=foobar&input=filled.count.soap&n-results=101",
+
+			expectedCode: w3wtest.BadNResults,
+		},
+		{
+			desc:  "given an invalid autosuggest focus, BadFocus returned",
+			query: "/autosuggest?key=foobar&input=filled.count.soap&focus=91,0",
+
+			expectedCode: w3wtest.BadFocus,
+		},
+		{
+			desc:  "given an invalid country clip, BadClipToCountry returned",
+			query: "/autosuggest?key=foobar&input=filled.count.soap&clip-to-country=GBR",
+
+			expectedCode: w3wtest.BadClipToCountry,
+		},
+		{
+			desc:  "given grid-section without a bounding box, MissingBoundingBox returned",
+			query: "/grid-section?key=foobar",
+
+			expectedCode: w3wtest.MissingBoundingBox,
+		},
+		{
+			desc:  "given an inverted bounding box, BadBoundingBox returned",
+			query: "/grid-section?key=foobar&bounding-box=52,0,51,1",
+
+			expectedCode: w3wtest.BadBoundingBox,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+			defer s.Close()
+
+			resp, err := http.Get(s.URL + tt.query)
+			assert.Nil(t, err)
+			defer resp.Body.Close()
+
+			var body struct {
+				Error w3w.Error `json:"error"`
+			}
+			assert.Nil(t, json.NewDecoder(resp.Body).Decode(&body))
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			assert.Equal(t, tt.expectedCode, body.Error.Code)
+		})
+	}
+}
+
+func TestServerAutoSuggestGrid(t *testing.T) {
+	s := w3wtest.NewGridServer(gridSeed)
+	defer s.Close()
+
+	c, err := w3w.New("foobar")
+	assert.Nil(t, err)
+
+	res := w3wtest.NewGrid(gridSeed).Result(51.520847, -0.195521)
+	input := res.Words[:len(res.Words)-3]
+
+	suggestions, err := c.AutoSuggest(input, w3w.AutoSuggestOptions{
+		APIURL:   s.URL,
+		NResults: 5,
+		Focus:    &res.Coordinates,
+	})
+	assert.Nil(t, err)
+
+	assert.NotEmpty(t, suggestions)
+	assert.LessOrEqual(t, len(suggestions), 5)
+	assert.Equal(t, res.Words, suggestions[0].Words)
+	assert.Equal(t, 0.0, suggestions[0].DistanceToFocusKm)
+}
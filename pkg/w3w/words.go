@@ -0,0 +1,111 @@
+package w3w
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const wordsPrefix = "///"
+
+// wordsHosts are the hosts of links to 3 word addresses
+var wordsHosts = []string{
+	"w3w.co",
+	"what3words.com",
+}
+
+// ParseError is returned by ParseWords for input which is not a 3 word
+// address. It wraps ErrEmptyWord, ErrInvalidWord or ErrInvalidNumberOfWords.
+type ParseError struct {
+	Input string
+	Err   error
+}
+
+// Error ...
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid 3 word address %q: %s", e.Input, e.Err)
+}
+
+// Unwrap returns the reason the input is invalid
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseWords parses a 3 word address written as filled.count.soap,
+// ///filled.count.soap or a w3w.co or what3words.com link, ignoring
+// surrounding whitespace and case. The words may be separated by any of the
+// separators accepted by IsPossible3wa. Invalid input returns a *ParseError.
+func ParseWords(s string) (Words, error) {
+	normalised := strings.ToLower(strings.TrimSpace(s))
+	normalised = trimWordsLink(normalised)
+	normalised = strings.TrimPrefix(normalised, wordsPrefix)
+
+	normalised = separatorReplacer.Replace(normalised)
+
+	parts := strings.Split(normalised, wordsDelimiter)
+	if len(parts) != len(Words{}) {
+		return Words{}, &ParseError{Input: s, Err: ErrInvalidNumberOfWords}
+	}
+
+	var words Words
+	for i, p := range parts {
+		if p == "" || strings.TrimSpace(p) != p {
+			return Words{}, &ParseError{Input: s, Err: ErrEmptyWord}
+		}
+		words[i] = p
+	}
+
+	if err := ValidateWords(words); err != nil {
+		return Words{}, &ParseError{Input: s, Err: err}
+	}
+
+	return words, nil
+}
+
+// String returns the address in its canonical dotted form, such as
+// filled.count.soap
+func (w Words) String() string {
+	return strings.Join(w[:], wordsDelimiter)
+}
+
+// trimWordsLink returns the address in a w3w.co or what3words.com link, or s
+// when it is not such a link
+func trimWordsLink(s string) string {
+	if !strings.Contains(s, "://") {
+		if !isWordsHost(s[:indexOrLen(s, "/")]) {
+			return s
+		}
+		s = "https://" + s
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || !isWordsHost(u.Hostname()) {
+		return s
+	}
+
+	path := strings.Trim(u.EscapedPath(), "/")
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		path = unescaped
+	}
+
+	// links may carry a language or other prefix before the address
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+func isWordsHost(host string) bool {
+	for _, h := range wordsHosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func indexOrLen(s, sep string) int {
+	if i := strings.Index(s, sep); i >= 0 {
+		return i
+	}
+
+	return len(s)
+}
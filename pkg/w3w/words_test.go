@@ -0,0 +1,100 @@
+package w3w_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWords(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		input string
+
+		expectedWords w3w.Words
+		expectedErr   error
+	}{
+		{
+			desc:  "given a dotted address, words returned",
+			input: "filled.count.soap",
+
+			expectedWords: w3w.Words{"filled", "count", "soap"},
+		},
+		{
+			desc:  "given a prefixed address with whitespace and mixed case, normalised words returned",
+			input: "  ///Filled.COUNT.soap\n",
+
+			expectedWords: w3w.Words{"filled", "count", "soap"},
+		},
+		{
+			desc:  "given a w3w.co link, words returned",
+			input: "https://w3w.co/filled.count.soap",
+
+			expectedWords: w3w.Words{"filled", "count", "soap"},
+		},
+		{
+			desc:  "given a what3words.com link without a scheme, words returned",
+			input: "what3words.com/filled.count.soap",
+
+			expectedWords: w3w.Words{"filled", "count", "soap"},
+		},
+		{
+			desc:  "given an escaped what3words.com link with a query, unescaped words returned",
+			input: "https://www.what3words.com/conduite.richissime.emp%C3%A2ter?lang=fr",
+
+			expectedWords: w3w.Words{"conduite", "richissime", "empâter"},
+		},
+		{
+			desc:  "given a link to another host, ErrInvalidNumberOfWords returned",
+			input: "https://example.com/filled.count.soap",
+
+			expectedErr: w3w.ErrInvalidNumberOfWords,
+		},
+		{
+			desc:  "given two words, ErrInvalidNumberOfWords returned",
+			input: "filled.count",
+
+			expectedErr: w3w.ErrInvalidNumberOfWords,
+		},
+		{
+			desc:  "given an address with an alternative separator, words returned",
+			input: "ｉｎｄｅｘ｡home。raft",
+
+			expectedWords: w3w.Words{"ｉｎｄｅｘ", "home", "raft"},
+		},
+		{
+			desc:  "given an empty word, ErrEmptyWord returned",
+			input: "filled..soap",
+
+			expectedErr: w3w.ErrEmptyWord,
+		},
+		{
+			desc:  "given a word containing digits, ErrInvalidWord returned",
+			input: "filled.count.s0ap",
+
+			expectedErr: w3w.ErrInvalidWord,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			words, err := w3w.ParseWords(tt.input)
+
+			if tt.expectedErr != nil {
+				var parseErr *w3w.ParseError
+				assert.True(t, errors.As(err, &parseErr))
+				assert.Equal(t, tt.input, parseErr.Input)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.expectedWords, words)
+			}
+		})
+	}
+}
+
+func TestWordsString(t *testing.T) {
+	assert.Equal(t, "filled.count.soap", w3w.Words{"filled", "count", "soap"}.String())
+}
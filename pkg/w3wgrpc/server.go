@@ -0,0 +1,480 @@
+// Package w3wgrpc serves the what3words gRPC service defined in
+// proto/w3w/v1/w3w.proto using a w3w.Service such as w3w.Client
+package w3wgrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wquota"
+	"github.com/jonnypillar/what3words/pkg/w3wpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultBulkWorkers = 4
+
+	errorDomain = "api.what3words.com"
+
+	// invalidArgumentCode is the error code reported in bulk responses for
+	// requests rejected before reaching the what3words API
+	invalidArgumentCode = "BadRequest"
+	// budgetExceededCode is reported for requests failed locally by a
+	// w3wquota budget
+	budgetExceededCode = "BudgetExceeded"
+	internalCode       = "Internal"
+
+	// unavailableMessage is returned in place of errors which are
+	// not the caller's, the detail being logged
+	unavailableMessage = "error calling the what3words API"
+)
+
+var (
+	errMissingRequest     = errors.New("a to_coordinates or to_words request is required")
+	errMissingCoordinates = errors.New("coordinates are required")
+	errMissingBoundingBox = errors.New("a bounding box with both corners is required")
+)
+
+// Options configures a Server
+type Options struct {
+	// APIURL overrides the what3words API URL used by the client
+	APIURL string
+	// BulkWorkers sets the number of conversions a BulkConvert stream runs
+	// concurrently, defaulting to 4
+	BulkWorkers int
+	// Logger receives the detail of errors hidden from callers, which are
+	// not logged when nil
+	Logger w3w.Logger
+}
+
+// Server implements w3wpb.What3WordsServiceServer
+type Server struct {
+	w3wpb.UnimplementedWhat3WordsServiceServer
+
+	client w3w.Service
+	opts   Options
+}
+
+// NewServer initialises a new Server answering requests with the client
+func NewServer(client w3w.Service, opts Options) *Server {
+	if opts.BulkWorkers < 1 {
+		opts.BulkWorkers = defaultBulkWorkers
+	}
+
+	return &Server{
+		client: client,
+		opts:   opts,
+	}
+}
+
+// ConvertToCoordinates converts a 3 word address into coordinates
+func (s *Server) ConvertToCoordinates(ctx context.Context, req *w3wpb.ConvertToCoordinatesRequest) (*w3wpb.ConvertToCoordinatesResponse, error) {
+	res, err := s.convertToCoordinates(ctx, req)
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	return &w3wpb.ConvertToCoordinatesResponse{
+		Result: res,
+	}, nil
+}
+
+// ConvertToWords converts coordinates into a 3 word address
+func (s *Server) ConvertToWords(ctx context.Context, req *w3wpb.ConvertToWordsRequest) (*w3wpb.ConvertToWordsResponse, error) {
+	res, err := s.convertToWords(ctx, req)
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	return &w3wpb.ConvertToWordsResponse{
+		Result: res,
+	}, nil
+}
+
+// BulkConvert converts each request received on the stream, sending the
+// responses as the conversions complete
+func (s *Server) BulkConvert(stream w3wpb.What3WordsService_BulkConvertServer) error {
+	ctx := stream.Context()
+
+	reqs := make(chan *w3wpb.BulkConvertRequest)
+	resps := make(chan *w3wpb.BulkConvertResponse)
+	recvErr := make(chan error, 1)
+
+	go func() {
+		defer close(reqs)
+
+		for {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				recvErr <- nil
+				return
+			}
+			if err != nil {
+				recvErr <- err
+				return
+			}
+
+			select {
+			case reqs <- req:
+			case <-ctx.Done():
+				recvErr <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < s.opts.BulkWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for req := range reqs {
+				resps <- s.bulkConvert(ctx, req)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(resps)
+	}()
+
+	var sendErr error
+	for resp := range resps {
+		// keep draining after a failed send so the workers can exit
+		if sendErr == nil {
+			sendErr = stream.Send(resp)
+		}
+	}
+
+	if sendErr != nil {
+		return sendErr
+	}
+
+	return <-recvErr
+}
+
+// AutoSuggest suggests 3 word addresses for partial or mistyped input
+func (s *Server) AutoSuggest(ctx context.Context, req *w3wpb.AutoSuggestRequest) (*w3wpb.AutoSuggestResponse, error) {
+	opts := w3w.AutoSuggestOptions{
+		APIURL:        s.opts.APIURL,
+		Language:      req.GetLanguage(),
+		NResults:      int(req.GetNResults()),
+		NFocusResults: int(req.GetNFocusResults()),
+		ClipToCountry: req.GetClipToCountry(),
+	}
+
+	if req.GetFocus() != nil {
+		focus := toLatLng(req.GetFocus())
+		opts.Focus = &focus
+	}
+
+	if box := req.GetClipToBoundingBox(); box != nil {
+		sq, err := toSquare(box)
+		if err != nil {
+			return nil, s.toStatus(ctx, err)
+		}
+		opts.ClipToBoundingBox = &sq
+	}
+
+	if circle := req.GetClipToCircle(); circle != nil {
+		if circle.GetCenter() == nil {
+			return nil, s.toStatus(ctx, errMissingCoordinates)
+		}
+
+		opts.ClipToCircle = &w3w.Circle{
+			Center:   toLatLng(circle.GetCenter()),
+			RadiusKm: circle.GetRadiusKm(),
+		}
+	}
+
+	suggestions, err := s.client.AutoSuggestContext(ctx, req.GetInput(), opts)
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	resp := &w3wpb.AutoSuggestResponse{
+		Suggestions: make([]*w3wpb.Suggestion, 0, len(suggestions)),
+	}
+	for _, sug := range suggestions {
+		resp.Suggestions = append(resp.Suggestions, &w3wpb.Suggestion{
+			Country:           sug.Country,
+			NearestPlace:      sug.NearestPlace,
+			Words:             sug.Words,
+			Rank:              int32(sug.Rank),
+			Language:          sug.Language,
+			DistanceToFocusKm: sug.DistanceToFocusKm,
+		})
+	}
+
+	return resp, nil
+}
+
+// GridSection returns the lines of the grid within a bounding box
+func (s *Server) GridSection(ctx context.Context, req *w3wpb.GridSectionRequest) (*w3wpb.GridSectionResponse, error) {
+	box, err := toSquare(req.GetBoundingBox())
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	lines, err := s.client.GridSectionContext(ctx, box, w3w.GridSectionOptions{
+		APIURL: s.opts.APIURL,
+	})
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	resp := &w3wpb.GridSectionResponse{
+		Lines: make([]*w3wpb.Line, 0, len(lines)),
+	}
+	for _, l := range lines {
+		resp.Lines = append(resp.Lines, &w3wpb.Line{
+			Start: toCoordinates(l.Start),
+			End:   toCoordinates(l.End),
+		})
+	}
+
+	return resp, nil
+}
+
+// AvailableLanguages lists the languages 3 word addresses are available in
+func (s *Server) AvailableLanguages(ctx context.Context, req *w3wpb.AvailableLanguagesRequest) (*w3wpb.AvailableLanguagesResponse, error) {
+	languages, err := s.client.AvailableLanguagesContext(ctx, w3w.LanguageOptions{
+		APIURL: s.opts.APIURL,
+	})
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	resp := &w3wpb.AvailableLanguagesResponse{
+		Languages: make([]*w3wpb.Language, 0, len(languages)),
+	}
+	for _, l := range languages {
+		resp.Languages = append(resp.Languages, &w3wpb.Language{
+			Code:       l.Code,
+			Name:       l.Name,
+			NativeName: l.NativeName,
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *Server) bulkConvert(ctx context.Context, req *w3wpb.BulkConvertRequest) *w3wpb.BulkConvertResponse {
+	var (
+		res *w3wpb.Result
+		err error
+	)
+
+	switch r := req.GetRequest().(type) {
+	case *w3wpb.BulkConvertRequest_ToCoordinates:
+		res, err = s.convertToCoordinates(ctx, r.ToCoordinates)
+	case *w3wpb.BulkConvertRequest_ToWords:
+		res, err = s.convertToWords(ctx, r.ToWords)
+	default:
+		err = errMissingRequest
+	}
+
+	resp := &w3wpb.BulkConvertResponse{
+		Id: req.GetId(),
+	}
+
+	if err != nil {
+		resp.Outcome = &w3wpb.BulkConvertResponse_Error{
+			Error: s.toError(ctx, err),
+		}
+		return resp
+	}
+
+	resp.Outcome = &w3wpb.BulkConvertResponse_Result{
+		Result: res,
+	}
+
+	return resp
+}
+
+func (s *Server) convertToCoordinates(ctx context.Context, req *w3wpb.ConvertToCoordinatesRequest) (*w3wpb.Result, error) {
+	words, err := w3w.ParseWords(req.GetWords())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.GetCoordinatesContext(ctx, words, w3w.CoordinateOptions{
+		APIURL: s.opts.APIURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toResult(res), nil
+}
+
+func (s *Server) convertToWords(ctx context.Context, req *w3wpb.ConvertToWordsRequest) (*w3wpb.Result, error) {
+	coords := req.GetCoordinates()
+	if coords == nil {
+		return nil, errMissingCoordinates
+	}
+
+	res, err := s.client.GetWordsContext(ctx, w3w.LatLng{
+		Lat: coords.GetLat(),
+		Lng: coords.GetLng(),
+	}, w3w.WordOptions{
+		APIURL:   s.opts.APIURL,
+		Language: req.GetLanguage(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toResult(res), nil
+}
+
+func toResult(res w3w.Result) *w3wpb.Result {
+	return &w3wpb.Result{
+		Country: res.Country,
+		Square: &w3wpb.Square{
+			Southwest: toCoordinates(res.Square.Southwest),
+			Northeast: toCoordinates(res.Square.Northeast),
+		},
+		NearestPlace: res.NearestPlace,
+		Coordinates:  toCoordinates(res.Coordinates),
+		Words:        res.Words,
+		Language:     res.Language,
+		Map:          res.Map,
+	}
+}
+
+func toCoordinates(c w3w.LatLng) *w3wpb.Coordinates {
+	return &w3wpb.Coordinates{
+		Lat: c.Lat,
+		Lng: c.Lng,
+	}
+}
+
+func toLatLng(c *w3wpb.Coordinates) w3w.LatLng {
+	return w3w.LatLng{
+		Lat: c.GetLat(),
+		Lng: c.GetLng(),
+	}
+}
+
+// toSquare converts a bounding box, which must have both corners
+func toSquare(sq *w3wpb.Square) (w3w.Square, error) {
+	if sq.GetSouthwest() == nil || sq.GetNortheast() == nil {
+		return w3w.Square{}, errMissingBoundingBox
+	}
+
+	return w3w.Square{
+		Southwest: toLatLng(sq.GetSouthwest()),
+		Northeast: toLatLng(sq.GetNortheast()),
+	}, nil
+}
+
+// toError converts err into the Error message returned in bulk responses
+func (s *Server) toError(ctx context.Context, err error) *w3wpb.Error {
+	var w3wErr w3w.Error
+	if errors.As(err, &w3wErr) {
+		return &w3wpb.Error{
+			Code:    w3wErr.Code,
+			Message: w3wErr.Message,
+		}
+	}
+
+	if isInvalidArgument(err) {
+		return &w3wpb.Error{
+			Code:    invalidArgumentCode,
+			Message: err.Error(),
+		}
+	}
+
+	if errors.Is(err, w3wquota.ErrBudgetExceeded) {
+		return &w3wpb.Error{
+			Code:    budgetExceededCode,
+			Message: err.Error(),
+		}
+	}
+
+	s.logHidden(ctx, err)
+
+	return &w3wpb.Error{
+		Code:    internalCode,
+		Message: unavailableMessage,
+	}
+}
+
+// toStatus converts err into a gRPC status. what3words API errors carry
+// their code as the reason of an ErrorInfo detail.
+func (s *Server) toStatus(ctx context.Context, err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	var w3wErr w3w.Error
+	if !errors.As(err, &w3wErr) {
+		if isInvalidArgument(err) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		if errors.Is(err, w3wquota.ErrBudgetExceeded) {
+			return status.Error(codes.ResourceExhausted, err.Error())
+		}
+
+		s.logHidden(ctx, err)
+
+		return status.Error(codes.Unavailable, unavailableMessage)
+	}
+
+	st := status.New(statusCode(w3wErr.Code), w3wErr.Error())
+
+	withInfo, detailsErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: w3wErr.Code,
+		Domain: errorDomain,
+	})
+	if detailsErr != nil {
+		return st.Err()
+	}
+
+	return withInfo.Err()
+}
+
+// logHidden logs an error whose detail is kept from callers, as it may
+// quote requests to the what3words API
+func (s *Server) logHidden(ctx context.Context, err error) {
+	if s.opts.Logger != nil {
+		s.opts.Logger.Log(ctx, w3w.LogLevelError, "w3w call failed", w3w.LogKeyError, err.Error())
+	}
+}
+
+// statusCode maps a what3words error code onto a gRPC code. Key errors refer
+// to the server's own key rather than the caller's request.
+func statusCode(code string) codes.Code {
+	switch code {
+	case "InvalidKey", "MissingKey", "SuspendedKey":
+		return codes.FailedPrecondition
+	case "QuotaExceeded":
+		return codes.ResourceExhausted
+	}
+
+	return codes.InvalidArgument
+}
+
+func isInvalidArgument(err error) bool {
+	return errors.Is(err, w3w.ErrInvalidNumberOfWords) ||
+		errors.Is(err, w3w.ErrEmptyWord) ||
+		errors.Is(err, w3w.ErrInvalidWord) ||
+		errors.Is(err, w3w.ErrLatitudeOutOfRange) ||
+		errors.Is(err, w3w.ErrLongitudeOutOfRange) ||
+		errors.Is(err, w3w.ErrInvalidCoordinates) ||
+		errors.Is(err, w3w.ErrEmptyInput) ||
+		errors.Is(err, w3w.ErrInvalidBoundingBox) ||
+		errors.Is(err, w3w.ErrInvalidOption) ||
+		errors.Is(err, errMissingRequest) ||
+		errors.Is(err, errMissingCoordinates) ||
+		errors.Is(err, errMissingBoundingBox)
+}
@@ -0,0 +1,567 @@
+package w3wgrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jonnypillar/what3words/internal/api"
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wquota"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+	"github.com/jonnypillar/what3words/pkg/w3wgrpc"
+	"github.com/jonnypillar/what3words/pkg/w3wpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+const apiKey = "foobar"
+
+func TestConvertToCoordinates(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		words string
+
+		expectedResult *w3wpb.Result
+		expectedCode   codes.Code
+		expectedReason string
+	}{
+		{
+			desc:  "given a 3 word address, result returned",
+			words: "one.two.three",
+
+			expectedResult: testResult(),
+		},
+		{
+			desc:  "given a 3 word address link in mixed case, result returned",
+			words: " https://w3w.co/One.Two.Three ",
+
+			expectedResult: testResult(),
+		},
+		{
+			desc:  "given a malformed 3 word address, invalid argument returned",
+			words: "one.two",
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			desc:  "given an empty word, invalid argument returned",
+			words: "one..three",
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			desc:  "given an invalid word, invalid argument returned",
+			words: "one.tw0.three",
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			desc:  "given the W3W API returns an error, error code returned in the status details",
+			words: "bad.bad.bad",
+
+			expectedCode:   codes.InvalidArgument,
+			expectedReason: "BadWords",
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			client, closeFn := testClient(t)
+			defer closeFn()
+
+			resp, err := client.ConvertToCoordinates(context.Background(), &w3wpb.ConvertToCoordinatesRequest{
+				Words: tt.words,
+			})
+
+			if tt.expectedCode != codes.OK {
+				st := status.Convert(err)
+				assert.Equal(t, tt.expectedCode, st.Code())
+
+				if tt.expectedReason != "" {
+					assert.Len(t, st.Details(), 1)
+					info, _ := st.Details()[0].(*errdetails.ErrorInfo)
+					assert.Equal(t, tt.expectedReason, info.GetReason())
+				}
+			} else {
+				assert.Nil(t, err)
+				assert.True(t, proto.Equal(tt.expectedResult, resp.GetResult()))
+			}
+		})
+	}
+}
+
+func TestConvertToWords(t *testing.T) {
+	client, closeFn := testClient(t)
+	defer closeFn()
+
+	resp, err := client.ConvertToWords(context.Background(), &w3wpb.ConvertToWordsRequest{
+		Coordinates: &w3wpb.Coordinates{Lat: 51.520847, Lng: -0.195521},
+		Language:    "en",
+	})
+
+	assert.Nil(t, err)
+	assert.True(t, proto.Equal(testResult(), resp.GetResult()))
+
+	_, err = client.ConvertToWords(context.Background(), &w3wpb.ConvertToWordsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.ConvertToWords(context.Background(), &w3wpb.ConvertToWordsRequest{
+		Coordinates: &w3wpb.Coordinates{Lat: math.NaN(), Lng: math.Inf(1)},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestBudgetExceeded(t *testing.T) {
+	tracker := w3wquota.NewTracker(w3wquota.TrackerOptions{
+		Budgets: []w3wquota.Budget{{Period: w3wquota.Daily, Hard: 1}},
+	})
+
+	client, closeFn := newTestClient(t, testServer(), w3wgrpc.Options{}, w3w.WithMiddleware(tracker.Middleware()))
+	defer closeFn()
+
+	req := &w3wpb.ConvertToCoordinatesRequest{Words: "one.two.three"}
+
+	_, err := client.ConvertToCoordinates(context.Background(), req)
+	assert.Nil(t, err)
+
+	_, err = client.ConvertToCoordinates(context.Background(), req)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestBulkConvert(t *testing.T) {
+	client, closeFn := testClient(t)
+	defer closeFn()
+
+	stream, err := client.BulkConvert(context.Background())
+	assert.Nil(t, err)
+
+	reqs := []*w3wpb.BulkConvertRequest{
+		{
+			Id: "1",
+			Request: &w3wpb.BulkConvertRequest_ToCoordinates{
+				ToCoordinates: &w3wpb.ConvertToCoordinatesRequest{Words: "one.two.three"},
+			},
+		},
+		{
+			Id: "2",
+			Request: &w3wpb.BulkConvertRequest_ToWords{
+				ToWords: &w3wpb.ConvertToWordsRequest{
+					Coordinates: &w3wpb.Coordinates{Lat: 51.520847, Lng: -0.195521},
+				},
+			},
+		},
+		{
+			Id: "3",
+			Request: &w3wpb.BulkConvertRequest_ToCoordinates{
+				ToCoordinates: &w3wpb.ConvertToCoordinatesRequest{Words: "bad.bad.bad"},
+			},
+		},
+		{
+			Id: "4",
+		},
+	}
+	for _, req := range reqs {
+		assert.Nil(t, stream.Send(req))
+	}
+	assert.Nil(t, stream.CloseSend())
+
+	var resps []*w3wpb.BulkConvertResponse
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		resps = append(resps, resp)
+	}
+
+	sort.Slice(resps, func(i, j int) bool {
+		return resps[i].GetId() < resps[j].GetId()
+	})
+
+	assert.Len(t, resps, 4)
+	assert.True(t, proto.Equal(testResult(), resps[0].GetResult()))
+	assert.True(t, proto.Equal(testResult(), resps[1].GetResult()))
+	assert.Equal(t, "BadWords", resps[2].GetError().GetCode())
+	assert.Equal(t, "BadRequest", resps[3].GetError().GetCode())
+}
+
+func TestConvertCancelled(t *testing.T) {
+	started := make(chan struct{})
+	aborted := make(chan struct{})
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+		close(aborted)
+	}))
+
+	client, closeFn := newTestClient(t, api, w3wgrpc.Options{})
+	defer closeFn()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	_, err := client.ConvertToCoordinates(ctx, &w3wpb.ConvertToCoordinatesRequest{
+		Words: "one.two.three",
+	})
+	assert.Equal(t, codes.Canceled, status.Code(err))
+
+	select {
+	case <-aborted:
+	case <-time.After(time.Second):
+		t.Fatal("upstream request not aborted")
+	}
+}
+
+func TestUnavailableHidesDetail(t *testing.T) {
+	// the API is unreachable, so the client's error quotes the request
+	api := httptest.NewServer(http.NotFoundHandler())
+	api.Close()
+
+	var logged []interface{}
+	client, closeFn := newTestClient(t, api, w3wgrpc.Options{
+		Logger: w3w.LoggerFunc(func(_ context.Context, _ w3w.LogLevel, _ string, args ...interface{}) {
+			logged = append(logged, args...)
+		}),
+	})
+	defer closeFn()
+
+	_, err := client.ConvertToCoordinates(context.Background(), &w3wpb.ConvertToCoordinatesRequest{
+		Words: "one.two.three",
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.NotContains(t, err.Error(), api.URL)
+
+	stream, err := client.BulkConvert(context.Background())
+	assert.Nil(t, err)
+	assert.Nil(t, stream.Send(&w3wpb.BulkConvertRequest{
+		Id: "1",
+		Request: &w3wpb.BulkConvertRequest_ToCoordinates{
+			ToCoordinates: &w3wpb.ConvertToCoordinatesRequest{Words: "one.two.three"},
+		},
+	}))
+	assert.Nil(t, stream.CloseSend())
+
+	resp, err := stream.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, "Internal", resp.GetError().GetCode())
+	assert.NotContains(t, resp.GetError().GetMessage(), api.URL)
+
+	// the detail is logged on the server, without the API key
+	assert.Len(t, logged, 4)
+	for _, a := range logged {
+		assert.NotContains(t, fmt.Sprint(a), apiKey)
+	}
+	assert.Contains(t, fmt.Sprint(logged...), api.URL)
+}
+
+func TestAutoSuggest(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		req      *w3wpb.AutoSuggestRequest
+		apiError string
+
+		expectedWords  []string
+		expectedCode   codes.Code
+		expectedReason string
+	}{
+		{
+			desc: "given partial words, suggestions returned",
+			req:  &w3wpb.AutoSuggestRequest{Input: "filled.count.so"},
+
+			expectedWords: []string{"filled.count.soap"},
+		},
+		{
+			desc: "given clip options, suggestions within them returned",
+			req: &w3wpb.AutoSuggestRequest{
+				Input:         "filled.count.so",
+				Focus:         &w3wpb.Coordinates{Lat: 51.52, Lng: -0.19},
+				ClipToCountry: []string{"GB"},
+				ClipToBoundingBox: &w3wpb.Square{
+					Southwest: &w3wpb.Coordinates{Lat: 51, Lng: -1},
+					Northeast: &w3wpb.Coordinates{Lat: 52, Lng: 0},
+				},
+				ClipToCircle: &w3wpb.Circle{Center: &w3wpb.Coordinates{Lat: 51.52, Lng: -0.19}, RadiusKm: 1},
+			},
+
+			expectedWords: []string{"filled.count.soap"},
+		},
+		{
+			desc: "given empty input, invalid argument returned",
+			req:  &w3wpb.AutoSuggestRequest{},
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			desc: "given a focus which is not a number, invalid argument returned",
+			req: &w3wpb.AutoSuggestRequest{
+				Input: "filled.count.so",
+				Focus: &w3wpb.Coordinates{Lat: math.NaN(), Lng: -0.19},
+			},
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			desc: "given a bounding box clip without a corner, invalid argument returned",
+			req: &w3wpb.AutoSuggestRequest{
+				Input:             "filled.count.so",
+				ClipToBoundingBox: &w3wpb.Square{Southwest: &w3wpb.Coordinates{Lat: 51, Lng: -1}},
+			},
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "given the W3W API returns an error, error code returned in the status details",
+			req:      &w3wpb.AutoSuggestRequest{Input: "filled.count.so"},
+			apiError: w3wtest.QuotaExceeded,
+
+			expectedCode:   codes.ResourceExhausted,
+			expectedReason: w3wtest.QuotaExceeded,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			s := w3wtest.NewServer(w3wtest.FilledCountSoap)
+			if tt.apiError != "" {
+				s.InjectError(w3wtest.RouteAutoSuggest, tt.apiError, 1)
+			}
+
+			client, closeFn := newTestClient(t, s.Server, w3wgrpc.Options{})
+			defer closeFn()
+
+			resp, err := client.AutoSuggest(context.Background(), tt.req)
+
+			if tt.expectedCode != codes.OK {
+				assertStatus(t, err, tt.expectedCode, tt.expectedReason)
+				return
+			}
+			assert.Nil(t, err)
+
+			var words []string
+			for _, sug := range resp.GetSuggestions() {
+				words = append(words, sug.GetWords())
+			}
+			assert.Equal(t, tt.expectedWords, words)
+		})
+	}
+}
+
+func TestAutoSuggestDistanceToFocus(t *testing.T) {
+	client, closeFn := newTestClient(t, w3wtest.NewServer(w3wtest.FilledCountSoap).Server, w3wgrpc.Options{})
+	defer closeFn()
+
+	focus := w3w.LatLng{Lat: 51.52, Lng: -0.19}
+
+	resp, err := client.AutoSuggest(context.Background(), &w3wpb.AutoSuggestRequest{
+		Input: "filled.count.soap",
+		Focus: &w3wpb.Coordinates{Lat: focus.Lat, Lng: focus.Lng},
+	})
+	assert.Nil(t, err)
+
+	assert.Len(t, resp.GetSuggestions(), 1)
+	assert.True(t, proto.Equal(&w3wpb.Suggestion{
+		Country:           "GB",
+		NearestPlace:      "Bayswater, London",
+		Words:             "filled.count.soap",
+		Rank:              1,
+		Language:          "en",
+		DistanceToFocusKm: focus.DistanceTo(w3wtest.FilledCountSoap.Coordinates) / 1000,
+	}, resp.GetSuggestions()[0]))
+}
+
+func TestGridSection(t *testing.T) {
+	testCases := []struct {
+		desc string
+		box  *w3wpb.Square
+
+		expectedLines  int
+		expectedCode   codes.Code
+		expectedReason string
+	}{
+		{
+			desc: "given a box around a square, its edges returned",
+			box: &w3wpb.Square{
+				Southwest: &w3wpb.Coordinates{Lat: 51.52, Lng: -0.196},
+				Northeast: &w3wpb.Coordinates{Lat: 51.521, Lng: -0.195},
+			},
+
+			expectedLines: 4,
+		},
+		{
+			desc: "given no bounding box, invalid argument returned",
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			desc: "given an inverted bounding box, invalid argument returned",
+			box: &w3wpb.Square{
+				Southwest: &w3wpb.Coordinates{Lat: 51.521, Lng: -0.196},
+				Northeast: &w3wpb.Coordinates{Lat: 51.52, Lng: -0.195},
+			},
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			desc: "given a box too big for the W3W API, error code returned in the status details",
+			box: &w3wpb.Square{
+				Southwest: &w3wpb.Coordinates{Lat: 51, Lng: -1},
+				Northeast: &w3wpb.Coordinates{Lat: 52, Lng: 0},
+			},
+
+			expectedCode:   codes.InvalidArgument,
+			expectedReason: w3wtest.BadBoundingBoxTooBig,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			client, closeFn := newTestClient(t, w3wtest.NewServer(w3wtest.FilledCountSoap).Server, w3wgrpc.Options{})
+			defer closeFn()
+
+			resp, err := client.GridSection(context.Background(), &w3wpb.GridSectionRequest{
+				BoundingBox: tt.box,
+			})
+
+			if tt.expectedCode != codes.OK {
+				assertStatus(t, err, tt.expectedCode, tt.expectedReason)
+				return
+			}
+			assert.Nil(t, err)
+
+			assert.Len(t, resp.GetLines(), tt.expectedLines)
+			assert.True(t, proto.Equal(&w3wpb.Line{
+				Start: &w3wpb.Coordinates{Lat: 51.520833, Lng: -0.195543},
+				End:   &w3wpb.Coordinates{Lat: 51.520833, Lng: -0.195499},
+			}, resp.GetLines()[0]))
+		})
+	}
+}
+
+func TestAvailableLanguages(t *testing.T) {
+	client, closeFn := newTestClient(t, w3wtest.NewServer().Server, w3wgrpc.Options{})
+	defer closeFn()
+
+	resp, err := client.AvailableLanguages(context.Background(), &w3wpb.AvailableLanguagesRequest{})
+	assert.Nil(t, err)
+
+	assert.Len(t, resp.GetLanguages(), len(w3wtest.Languages))
+	assert.True(t, proto.Equal(&w3wpb.Language{
+		Code:       w3wtest.Languages[0].Code,
+		Name:       w3wtest.Languages[0].Name,
+		NativeName: w3wtest.Languages[0].NativeName,
+	}, resp.GetLanguages()[0]))
+}
+
+func assertStatus(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+
+	st := status.Convert(err)
+	assert.Equal(t, code, st.Code())
+
+	if reason != "" {
+		assert.Len(t, st.Details(), 1)
+		info, _ := st.Details()[0].(*errdetails.ErrorInfo)
+		assert.Equal(t, reason, info.GetReason())
+	}
+}
+
+// testClient starts a Server backed by a fake W3W API, returning a client
+// connected to it and a function to close both
+func testClient(t *testing.T) (w3wpb.What3WordsServiceClient, func()) {
+	return newTestClient(t, testServer(), w3wgrpc.Options{})
+}
+
+// newTestClient starts a Server with opts, backed by api through a client
+// with clientOpts, returning a client connected to it and a function to
+// close both
+func newTestClient(t *testing.T, api *httptest.Server, opts w3wgrpc.Options, clientOpts ...w3w.Option) (w3wpb.What3WordsServiceClient, func()) {
+	c, err := w3w.New(apiKey, clientOpts...)
+	assert.Nil(t, err)
+
+	lis := bufconn.Listen(1024 * 1024)
+
+	srv := grpc.NewServer()
+	opts.APIURL = api.URL
+	w3wpb.RegisterWhat3WordsServiceServer(srv, w3wgrpc.NewServer(c, opts))
+	go srv.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.Nil(t, err)
+
+	return w3wpb.NewWhat3WordsServiceClient(conn), func() {
+		conn.Close()
+		srv.Stop()
+		api.Close()
+	}
+}
+
+func testResult() *w3wpb.Result {
+	return &w3wpb.Result{
+		Country: "GB",
+		Square: &w3wpb.Square{
+			Southwest: &w3wpb.Coordinates{Lat: 51.520833, Lng: -0.195543},
+			Northeast: &w3wpb.Coordinates{Lat: 51.52086, Lng: -0.195499},
+		},
+		NearestPlace: "Bayswater, London",
+		Coordinates:  &w3wpb.Coordinates{Lat: 51.520847, Lng: -0.195521},
+		Words:        "one.two.three",
+		Language:     "en",
+		Map:          "https://w3w.co/one.two.three",
+	}
+}
+
+func testServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("words") == "bad.bad.bad" {
+			var errResp api.ErrorResponse
+			errResp.Err.Code = "BadWords"
+			errResp.Err.Message = "Invalid or non-existent 3 word address"
+
+			b, _ := json.Marshal(errResp)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(b)
+			return
+		}
+
+		var resp api.Response
+		resp.Country = "GB"
+		resp.Square.Southwest.Lat = 51.520833
+		resp.Square.Southwest.Lng = -0.195543
+		resp.Square.Northeast.Lat = 51.52086
+		resp.Square.Northeast.Lng = -0.195499
+		resp.NearestPlace = "Bayswater, London"
+		resp.Coordinates.Lat = 51.520847
+		resp.Coordinates.Lng = -0.195521
+		resp.Words = "one.two.three"
+		resp.Language = "en"
+		resp.Map = "https://w3w.co/one.two.three"
+
+		b, _ := json.Marshal(resp)
+		w.Write(b)
+	}))
+}
@@ -0,0 +1,8 @@
+// Package w3wpb contains the Go code generated from the what3words protobuf
+// service definition in proto/w3w/v1/w3w.proto
+//
+// Run `go generate` in this package, with buf, protoc-gen-go and
+// protoc-gen-go-grpc installed, after changing the definition.
+package w3wpb
+
+//go:generate sh -c "cd ../../proto && buf generate"
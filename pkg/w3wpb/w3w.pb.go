@@ -0,0 +1,1411 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: w3w/v1/w3w.proto
+
+package w3wpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Coordinates mirrors w3w.Coordinates.
+type Coordinates struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lat           float64                `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng           float64                `protobuf:"fixed64,2,opt,name=lng,proto3" json:"lng,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Coordinates) Reset() {
+	*x = Coordinates{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coordinates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coordinates) ProtoMessage() {}
+
+func (x *Coordinates) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coordinates.ProtoReflect.Descriptor instead.
+func (*Coordinates) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Coordinates) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *Coordinates) GetLng() float64 {
+	if x != nil {
+		return x.Lng
+	}
+	return 0
+}
+
+// Square mirrors w3w.Square, the bounds of a 3m grid square.
+type Square struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Southwest     *Coordinates           `protobuf:"bytes,1,opt,name=southwest,proto3" json:"southwest,omitempty"`
+	Northeast     *Coordinates           `protobuf:"bytes,2,opt,name=northeast,proto3" json:"northeast,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Square) Reset() {
+	*x = Square{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Square) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Square) ProtoMessage() {}
+
+func (x *Square) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Square.ProtoReflect.Descriptor instead.
+func (*Square) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Square) GetSouthwest() *Coordinates {
+	if x != nil {
+		return x.Southwest
+	}
+	return nil
+}
+
+func (x *Square) GetNortheast() *Coordinates {
+	if x != nil {
+		return x.Northeast
+	}
+	return nil
+}
+
+// Result mirrors w3w.Result.
+type Result struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Country       string                 `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	Square        *Square                `protobuf:"bytes,2,opt,name=square,proto3" json:"square,omitempty"`
+	NearestPlace  string                 `protobuf:"bytes,3,opt,name=nearest_place,json=nearestPlace,proto3" json:"nearest_place,omitempty"`
+	Coordinates   *Coordinates           `protobuf:"bytes,4,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	Words         string                 `protobuf:"bytes,5,opt,name=words,proto3" json:"words,omitempty"`
+	Language      string                 `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`
+	Map           string                 `protobuf:"bytes,7,opt,name=map,proto3" json:"map,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Result) Reset() {
+	*x = Result{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Result) ProtoMessage() {}
+
+func (x *Result) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Result.ProtoReflect.Descriptor instead.
+func (*Result) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Result) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Result) GetSquare() *Square {
+	if x != nil {
+		return x.Square
+	}
+	return nil
+}
+
+func (x *Result) GetNearestPlace() string {
+	if x != nil {
+		return x.NearestPlace
+	}
+	return ""
+}
+
+func (x *Result) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+func (x *Result) GetWords() string {
+	if x != nil {
+		return x.Words
+	}
+	return ""
+}
+
+func (x *Result) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Result) GetMap() string {
+	if x != nil {
+		return x.Map
+	}
+	return ""
+}
+
+// Error mirrors w3w.Error, carrying the what3words API's error code such as
+// BadWords or QuotaExceeded.
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Error) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ConvertToCoordinatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// words is the 3 word address in its dotted form, e.g. filled.count.soap.
+	Words         string `protobuf:"bytes,1,opt,name=words,proto3" json:"words,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertToCoordinatesRequest) Reset() {
+	*x = ConvertToCoordinatesRequest{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertToCoordinatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertToCoordinatesRequest) ProtoMessage() {}
+
+func (x *ConvertToCoordinatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertToCoordinatesRequest.ProtoReflect.Descriptor instead.
+func (*ConvertToCoordinatesRequest) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ConvertToCoordinatesRequest) GetWords() string {
+	if x != nil {
+		return x.Words
+	}
+	return ""
+}
+
+type ConvertToCoordinatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *Result                `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertToCoordinatesResponse) Reset() {
+	*x = ConvertToCoordinatesResponse{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertToCoordinatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertToCoordinatesResponse) ProtoMessage() {}
+
+func (x *ConvertToCoordinatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertToCoordinatesResponse.ProtoReflect.Descriptor instead.
+func (*ConvertToCoordinatesResponse) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ConvertToCoordinatesResponse) GetResult() *Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type ConvertToWordsRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Coordinates *Coordinates           `protobuf:"bytes,1,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	// language is the language of the returned 3 word address, the API's
+	// default being used when empty.
+	Language      string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertToWordsRequest) Reset() {
+	*x = ConvertToWordsRequest{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertToWordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertToWordsRequest) ProtoMessage() {}
+
+func (x *ConvertToWordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertToWordsRequest.ProtoReflect.Descriptor instead.
+func (*ConvertToWordsRequest) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ConvertToWordsRequest) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+func (x *ConvertToWordsRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type ConvertToWordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *Result                `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertToWordsResponse) Reset() {
+	*x = ConvertToWordsResponse{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertToWordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertToWordsResponse) ProtoMessage() {}
+
+func (x *ConvertToWordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertToWordsResponse.ProtoReflect.Descriptor instead.
+func (*ConvertToWordsResponse) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ConvertToWordsResponse) GetResult() *Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type BulkConvertRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is returned in the matching response.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are valid to be assigned to Request:
+	//
+	//	*BulkConvertRequest_ToCoordinates
+	//	*BulkConvertRequest_ToWords
+	Request       isBulkConvertRequest_Request `protobuf_oneof:"request"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkConvertRequest) Reset() {
+	*x = BulkConvertRequest{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkConvertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkConvertRequest) ProtoMessage() {}
+
+func (x *BulkConvertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkConvertRequest.ProtoReflect.Descriptor instead.
+func (*BulkConvertRequest) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BulkConvertRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BulkConvertRequest) GetRequest() isBulkConvertRequest_Request {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *BulkConvertRequest) GetToCoordinates() *ConvertToCoordinatesRequest {
+	if x != nil {
+		if x, ok := x.Request.(*BulkConvertRequest_ToCoordinates); ok {
+			return x.ToCoordinates
+		}
+	}
+	return nil
+}
+
+func (x *BulkConvertRequest) GetToWords() *ConvertToWordsRequest {
+	if x != nil {
+		if x, ok := x.Request.(*BulkConvertRequest_ToWords); ok {
+			return x.ToWords
+		}
+	}
+	return nil
+}
+
+type isBulkConvertRequest_Request interface {
+	isBulkConvertRequest_Request()
+}
+
+type BulkConvertRequest_ToCoordinates struct {
+	ToCoordinates *ConvertToCoordinatesRequest `protobuf:"bytes,2,opt,name=to_coordinates,json=toCoordinates,proto3,oneof"`
+}
+
+type BulkConvertRequest_ToWords struct {
+	ToWords *ConvertToWordsRequest `protobuf:"bytes,3,opt,name=to_words,json=toWords,proto3,oneof"`
+}
+
+func (*BulkConvertRequest_ToCoordinates) isBulkConvertRequest_Request() {}
+
+func (*BulkConvertRequest_ToWords) isBulkConvertRequest_Request() {}
+
+type BulkConvertResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are valid to be assigned to Outcome:
+	//
+	//	*BulkConvertResponse_Result
+	//	*BulkConvertResponse_Error
+	Outcome       isBulkConvertResponse_Outcome `protobuf_oneof:"outcome"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkConvertResponse) Reset() {
+	*x = BulkConvertResponse{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkConvertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkConvertResponse) ProtoMessage() {}
+
+func (x *BulkConvertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkConvertResponse.ProtoReflect.Descriptor instead.
+func (*BulkConvertResponse) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BulkConvertResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BulkConvertResponse) GetOutcome() isBulkConvertResponse_Outcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return nil
+}
+
+func (x *BulkConvertResponse) GetResult() *Result {
+	if x != nil {
+		if x, ok := x.Outcome.(*BulkConvertResponse_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+func (x *BulkConvertResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Outcome.(*BulkConvertResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isBulkConvertResponse_Outcome interface {
+	isBulkConvertResponse_Outcome()
+}
+
+type BulkConvertResponse_Result struct {
+	Result *Result `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+type BulkConvertResponse_Error struct {
+	Error *Error `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+func (*BulkConvertResponse_Result) isBulkConvertResponse_Outcome() {}
+
+func (*BulkConvertResponse_Error) isBulkConvertResponse_Outcome() {}
+
+// Suggestion mirrors w3w.Suggestion.
+type Suggestion struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Country      string                 `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	NearestPlace string                 `protobuf:"bytes,2,opt,name=nearest_place,json=nearestPlace,proto3" json:"nearest_place,omitempty"`
+	Words        string                 `protobuf:"bytes,3,opt,name=words,proto3" json:"words,omitempty"`
+	// rank orders the suggestions, 1 being the most likely.
+	Rank     int32  `protobuf:"varint,4,opt,name=rank,proto3" json:"rank,omitempty"`
+	Language string `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`
+	// distance_to_focus_km is only set when a focus is given.
+	DistanceToFocusKm float64 `protobuf:"fixed64,6,opt,name=distance_to_focus_km,json=distanceToFocusKm,proto3" json:"distance_to_focus_km,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Suggestion) Reset() {
+	*x = Suggestion{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Suggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Suggestion) ProtoMessage() {}
+
+func (x *Suggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Suggestion.ProtoReflect.Descriptor instead.
+func (*Suggestion) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Suggestion) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Suggestion) GetNearestPlace() string {
+	if x != nil {
+		return x.NearestPlace
+	}
+	return ""
+}
+
+func (x *Suggestion) GetWords() string {
+	if x != nil {
+		return x.Words
+	}
+	return ""
+}
+
+func (x *Suggestion) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *Suggestion) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Suggestion) GetDistanceToFocusKm() float64 {
+	if x != nil {
+		return x.DistanceToFocusKm
+	}
+	return 0
+}
+
+// Circle mirrors w3w.Circle.
+type Circle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Center        *Coordinates           `protobuf:"bytes,1,opt,name=center,proto3" json:"center,omitempty"`
+	RadiusKm      float64                `protobuf:"fixed64,2,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Circle) Reset() {
+	*x = Circle{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Circle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Circle) ProtoMessage() {}
+
+func (x *Circle) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Circle.ProtoReflect.Descriptor instead.
+func (*Circle) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Circle) GetCenter() *Coordinates {
+	if x != nil {
+		return x.Center
+	}
+	return nil
+}
+
+func (x *Circle) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+type AutoSuggestRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Input string                 `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+	// language is the language of the suggestions, the API's default being
+	// used when empty.
+	Language string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	// n_results is the number of suggestions returned, 3 when 0 and at most
+	// 100.
+	NResults int32 `protobuf:"varint,3,opt,name=n_results,json=nResults,proto3" json:"n_results,omitempty"`
+	// focus ranks suggestions near the point higher.
+	Focus *Coordinates `protobuf:"bytes,4,opt,name=focus,proto3" json:"focus,omitempty"`
+	// n_focus_results is how many of the suggestions are ranked by focus.
+	NFocusResults int32 `protobuf:"varint,5,opt,name=n_focus_results,json=nFocusResults,proto3" json:"n_focus_results,omitempty"`
+	// clip_to_country restricts suggestions to ISO 3166-1 alpha-2 codes.
+	ClipToCountry     []string `protobuf:"bytes,6,rep,name=clip_to_country,json=clipToCountry,proto3" json:"clip_to_country,omitempty"`
+	ClipToBoundingBox *Square  `protobuf:"bytes,7,opt,name=clip_to_bounding_box,json=clipToBoundingBox,proto3" json:"clip_to_bounding_box,omitempty"`
+	ClipToCircle      *Circle  `protobuf:"bytes,8,opt,name=clip_to_circle,json=clipToCircle,proto3" json:"clip_to_circle,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AutoSuggestRequest) Reset() {
+	*x = AutoSuggestRequest{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutoSuggestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoSuggestRequest) ProtoMessage() {}
+
+func (x *AutoSuggestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoSuggestRequest.ProtoReflect.Descriptor instead.
+func (*AutoSuggestRequest) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AutoSuggestRequest) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *AutoSuggestRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *AutoSuggestRequest) GetNResults() int32 {
+	if x != nil {
+		return x.NResults
+	}
+	return 0
+}
+
+func (x *AutoSuggestRequest) GetFocus() *Coordinates {
+	if x != nil {
+		return x.Focus
+	}
+	return nil
+}
+
+func (x *AutoSuggestRequest) GetNFocusResults() int32 {
+	if x != nil {
+		return x.NFocusResults
+	}
+	return 0
+}
+
+func (x *AutoSuggestRequest) GetClipToCountry() []string {
+	if x != nil {
+		return x.ClipToCountry
+	}
+	return nil
+}
+
+func (x *AutoSuggestRequest) GetClipToBoundingBox() *Square {
+	if x != nil {
+		return x.ClipToBoundingBox
+	}
+	return nil
+}
+
+func (x *AutoSuggestRequest) GetClipToCircle() *Circle {
+	if x != nil {
+		return x.ClipToCircle
+	}
+	return nil
+}
+
+type AutoSuggestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*Suggestion          `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AutoSuggestResponse) Reset() {
+	*x = AutoSuggestResponse{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutoSuggestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoSuggestResponse) ProtoMessage() {}
+
+func (x *AutoSuggestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoSuggestResponse.ProtoReflect.Descriptor instead.
+func (*AutoSuggestResponse) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AutoSuggestResponse) GetSuggestions() []*Suggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+// Line mirrors w3w.Line.
+type Line struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *Coordinates           `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           *Coordinates           `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Line) Reset() {
+	*x = Line{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Line) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Line) ProtoMessage() {}
+
+func (x *Line) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Line.ProtoReflect.Descriptor instead.
+func (*Line) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Line) GetStart() *Coordinates {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *Line) GetEnd() *Coordinates {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+type GridSectionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// bounding_box may have a diagonal of at most 4km.
+	BoundingBox   *Square `protobuf:"bytes,1,opt,name=bounding_box,json=boundingBox,proto3" json:"bounding_box,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GridSectionRequest) Reset() {
+	*x = GridSectionRequest{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GridSectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GridSectionRequest) ProtoMessage() {}
+
+func (x *GridSectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GridSectionRequest.ProtoReflect.Descriptor instead.
+func (*GridSectionRequest) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GridSectionRequest) GetBoundingBox() *Square {
+	if x != nil {
+		return x.BoundingBox
+	}
+	return nil
+}
+
+type GridSectionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lines         []*Line                `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GridSectionResponse) Reset() {
+	*x = GridSectionResponse{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GridSectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GridSectionResponse) ProtoMessage() {}
+
+func (x *GridSectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GridSectionResponse.ProtoReflect.Descriptor instead.
+func (*GridSectionResponse) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GridSectionResponse) GetLines() []*Line {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+// Language mirrors w3w.Language.
+type Language struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	NativeName    string                 `protobuf:"bytes,3,opt,name=native_name,json=nativeName,proto3" json:"native_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Language) Reset() {
+	*x = Language{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Language) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Language) ProtoMessage() {}
+
+func (x *Language) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Language.ProtoReflect.Descriptor instead.
+func (*Language) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Language) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Language) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Language) GetNativeName() string {
+	if x != nil {
+		return x.NativeName
+	}
+	return ""
+}
+
+type AvailableLanguagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AvailableLanguagesRequest) Reset() {
+	*x = AvailableLanguagesRequest{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AvailableLanguagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvailableLanguagesRequest) ProtoMessage() {}
+
+func (x *AvailableLanguagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvailableLanguagesRequest.ProtoReflect.Descriptor instead.
+func (*AvailableLanguagesRequest) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{18}
+}
+
+type AvailableLanguagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Languages     []*Language            `protobuf:"bytes,1,rep,name=languages,proto3" json:"languages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AvailableLanguagesResponse) Reset() {
+	*x = AvailableLanguagesResponse{}
+	mi := &file_w3w_v1_w3w_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AvailableLanguagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvailableLanguagesResponse) ProtoMessage() {}
+
+func (x *AvailableLanguagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_w3w_v1_w3w_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvailableLanguagesResponse.ProtoReflect.Descriptor instead.
+func (*AvailableLanguagesResponse) Descriptor() ([]byte, []int) {
+	return file_w3w_v1_w3w_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AvailableLanguagesResponse) GetLanguages() []*Language {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+var File_w3w_v1_w3w_proto protoreflect.FileDescriptor
+
+const file_w3w_v1_w3w_proto_rawDesc = "" +
+	"\n" +
+	"\x10w3w/v1/w3w.proto\x12\x06w3w.v1\"1\n" +
+	"\vCoordinates\x12\x10\n" +
+	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lng\x18\x02 \x01(\x01R\x03lng\"n\n" +
+	"\x06Square\x121\n" +
+	"\tsouthwest\x18\x01 \x01(\v2\x13.w3w.v1.CoordinatesR\tsouthwest\x121\n" +
+	"\tnortheast\x18\x02 \x01(\v2\x13.w3w.v1.CoordinatesR\tnortheast\"\xea\x01\n" +
+	"\x06Result\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\x12&\n" +
+	"\x06square\x18\x02 \x01(\v2\x0e.w3w.v1.SquareR\x06square\x12#\n" +
+	"\rnearest_place\x18\x03 \x01(\tR\fnearestPlace\x125\n" +
+	"\vcoordinates\x18\x04 \x01(\v2\x13.w3w.v1.CoordinatesR\vcoordinates\x12\x14\n" +
+	"\x05words\x18\x05 \x01(\tR\x05words\x12\x1a\n" +
+	"\blanguage\x18\x06 \x01(\tR\blanguage\x12\x10\n" +
+	"\x03map\x18\a \x01(\tR\x03map\"5\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"3\n" +
+	"\x1bConvertToCoordinatesRequest\x12\x14\n" +
+	"\x05words\x18\x01 \x01(\tR\x05words\"F\n" +
+	"\x1cConvertToCoordinatesResponse\x12&\n" +
+	"\x06result\x18\x01 \x01(\v2\x0e.w3w.v1.ResultR\x06result\"j\n" +
+	"\x15ConvertToWordsRequest\x125\n" +
+	"\vcoordinates\x18\x01 \x01(\v2\x13.w3w.v1.CoordinatesR\vcoordinates\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\"@\n" +
+	"\x16ConvertToWordsResponse\x12&\n" +
+	"\x06result\x18\x01 \x01(\v2\x0e.w3w.v1.ResultR\x06result\"\xb9\x01\n" +
+	"\x12BulkConvertRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12L\n" +
+	"\x0eto_coordinates\x18\x02 \x01(\v2#.w3w.v1.ConvertToCoordinatesRequestH\x00R\rtoCoordinates\x12:\n" +
+	"\bto_words\x18\x03 \x01(\v2\x1d.w3w.v1.ConvertToWordsRequestH\x00R\atoWordsB\t\n" +
+	"\arequest\"\x81\x01\n" +
+	"\x13BulkConvertResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\x06result\x18\x02 \x01(\v2\x0e.w3w.v1.ResultH\x00R\x06result\x12%\n" +
+	"\x05error\x18\x03 \x01(\v2\r.w3w.v1.ErrorH\x00R\x05errorB\t\n" +
+	"\aoutcome\"\xc2\x01\n" +
+	"\n" +
+	"Suggestion\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\x12#\n" +
+	"\rnearest_place\x18\x02 \x01(\tR\fnearestPlace\x12\x14\n" +
+	"\x05words\x18\x03 \x01(\tR\x05words\x12\x12\n" +
+	"\x04rank\x18\x04 \x01(\x05R\x04rank\x12\x1a\n" +
+	"\blanguage\x18\x05 \x01(\tR\blanguage\x12/\n" +
+	"\x14distance_to_focus_km\x18\x06 \x01(\x01R\x11distanceToFocusKm\"R\n" +
+	"\x06Circle\x12+\n" +
+	"\x06center\x18\x01 \x01(\v2\x13.w3w.v1.CoordinatesR\x06center\x12\x1b\n" +
+	"\tradius_km\x18\x02 \x01(\x01R\bradiusKm\"\xd5\x02\n" +
+	"\x12AutoSuggestRequest\x12\x14\n" +
+	"\x05input\x18\x01 \x01(\tR\x05input\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x1b\n" +
+	"\tn_results\x18\x03 \x01(\x05R\bnResults\x12)\n" +
+	"\x05focus\x18\x04 \x01(\v2\x13.w3w.v1.CoordinatesR\x05focus\x12&\n" +
+	"\x0fn_focus_results\x18\x05 \x01(\x05R\rnFocusResults\x12&\n" +
+	"\x0fclip_to_country\x18\x06 \x03(\tR\rclipToCountry\x12?\n" +
+	"\x14clip_to_bounding_box\x18\a \x01(\v2\x0e.w3w.v1.SquareR\x11clipToBoundingBox\x124\n" +
+	"\x0eclip_to_circle\x18\b \x01(\v2\x0e.w3w.v1.CircleR\fclipToCircle\"K\n" +
+	"\x13AutoSuggestResponse\x124\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x12.w3w.v1.SuggestionR\vsuggestions\"X\n" +
+	"\x04Line\x12)\n" +
+	"\x05start\x18\x01 \x01(\v2\x13.w3w.v1.CoordinatesR\x05start\x12%\n" +
+	"\x03end\x18\x02 \x01(\v2\x13.w3w.v1.CoordinatesR\x03end\"G\n" +
+	"\x12GridSectionRequest\x121\n" +
+	"\fbounding_box\x18\x01 \x01(\v2\x0e.w3w.v1.SquareR\vboundingBox\"9\n" +
+	"\x13GridSectionResponse\x12\"\n" +
+	"\x05lines\x18\x01 \x03(\v2\f.w3w.v1.LineR\x05lines\"S\n" +
+	"\bLanguage\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vnative_name\x18\x03 \x01(\tR\n" +
+	"nativeName\"\x1b\n" +
+	"\x19AvailableLanguagesRequest\"L\n" +
+	"\x1aAvailableLanguagesResponse\x12.\n" +
+	"\tlanguages\x18\x01 \x03(\v2\x10.w3w.v1.LanguageR\tlanguages2\x80\x04\n" +
+	"\x11What3WordsService\x12a\n" +
+	"\x14ConvertToCoordinates\x12#.w3w.v1.ConvertToCoordinatesRequest\x1a$.w3w.v1.ConvertToCoordinatesResponse\x12O\n" +
+	"\x0eConvertToWords\x12\x1d.w3w.v1.ConvertToWordsRequest\x1a\x1e.w3w.v1.ConvertToWordsResponse\x12J\n" +
+	"\vBulkConvert\x12\x1a.w3w.v1.BulkConvertRequest\x1a\x1b.w3w.v1.BulkConvertResponse(\x010\x01\x12F\n" +
+	"\vAutoSuggest\x12\x1a.w3w.v1.AutoSuggestRequest\x1a\x1b.w3w.v1.AutoSuggestResponse\x12F\n" +
+	"\vGridSection\x12\x1a.w3w.v1.GridSectionRequest\x1a\x1b.w3w.v1.GridSectionResponse\x12[\n" +
+	"\x12AvailableLanguages\x12!.w3w.v1.AvailableLanguagesRequest\x1a\".w3w.v1.AvailableLanguagesResponseB3Z1github.com/jonnypillar/what3words/pkg/w3wpb;w3wpbb\x06proto3"
+
+var (
+	file_w3w_v1_w3w_proto_rawDescOnce sync.Once
+	file_w3w_v1_w3w_proto_rawDescData []byte
+)
+
+func file_w3w_v1_w3w_proto_rawDescGZIP() []byte {
+	file_w3w_v1_w3w_proto_rawDescOnce.Do(func() {
+		file_w3w_v1_w3w_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_w3w_v1_w3w_proto_rawDesc), len(file_w3w_v1_w3w_proto_rawDesc)))
+	})
+	return file_w3w_v1_w3w_proto_rawDescData
+}
+
+var file_w3w_v1_w3w_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_w3w_v1_w3w_proto_goTypes = []any{
+	(*Coordinates)(nil),                  // 0: w3w.v1.Coordinates
+	(*Square)(nil),                       // 1: w3w.v1.Square
+	(*Result)(nil),                       // 2: w3w.v1.Result
+	(*Error)(nil),                        // 3: w3w.v1.Error
+	(*ConvertToCoordinatesRequest)(nil),  // 4: w3w.v1.ConvertToCoordinatesRequest
+	(*ConvertToCoordinatesResponse)(nil), // 5: w3w.v1.ConvertToCoordinatesResponse
+	(*ConvertToWordsRequest)(nil),        // 6: w3w.v1.ConvertToWordsRequest
+	(*ConvertToWordsResponse)(nil),       // 7: w3w.v1.ConvertToWordsResponse
+	(*BulkConvertRequest)(nil),           // 8: w3w.v1.BulkConvertRequest
+	(*BulkConvertResponse)(nil),          // 9: w3w.v1.BulkConvertResponse
+	(*Suggestion)(nil),                   // 10: w3w.v1.Suggestion
+	(*Circle)(nil),                       // 11: w3w.v1.Circle
+	(*AutoSuggestRequest)(nil),           // 12: w3w.v1.AutoSuggestRequest
+	(*AutoSuggestResponse)(nil),          // 13: w3w.v1.AutoSuggestResponse
+	(*Line)(nil),                         // 14: w3w.v1.Line
+	(*GridSectionRequest)(nil),           // 15: w3w.v1.GridSectionRequest
+	(*GridSectionResponse)(nil),          // 16: w3w.v1.GridSectionResponse
+	(*Language)(nil),                     // 17: w3w.v1.Language
+	(*AvailableLanguagesRequest)(nil),    // 18: w3w.v1.AvailableLanguagesRequest
+	(*AvailableLanguagesResponse)(nil),   // 19: w3w.v1.AvailableLanguagesResponse
+}
+var file_w3w_v1_w3w_proto_depIdxs = []int32{
+	0,  // 0: w3w.v1.Square.southwest:type_name -> w3w.v1.Coordinates
+	0,  // 1: w3w.v1.Square.northeast:type_name -> w3w.v1.Coordinates
+	1,  // 2: w3w.v1.Result.square:type_name -> w3w.v1.Square
+	0,  // 3: w3w.v1.Result.coordinates:type_name -> w3w.v1.Coordinates
+	2,  // 4: w3w.v1.ConvertToCoordinatesResponse.result:type_name -> w3w.v1.Result
+	0,  // 5: w3w.v1.ConvertToWordsRequest.coordinates:type_name -> w3w.v1.Coordinates
+	2,  // 6: w3w.v1.ConvertToWordsResponse.result:type_name -> w3w.v1.Result
+	4,  // 7: w3w.v1.BulkConvertRequest.to_coordinates:type_name -> w3w.v1.ConvertToCoordinatesRequest
+	6,  // 8: w3w.v1.BulkConvertRequest.to_words:type_name -> w3w.v1.ConvertToWordsRequest
+	2,  // 9: w3w.v1.BulkConvertResponse.result:type_name -> w3w.v1.Result
+	3,  // 10: w3w.v1.BulkConvertResponse.error:type_name -> w3w.v1.Error
+	0,  // 11: w3w.v1.Circle.center:type_name -> w3w.v1.Coordinates
+	0,  // 12: w3w.v1.AutoSuggestRequest.focus:type_name -> w3w.v1.Coordinates
+	1,  // 13: w3w.v1.AutoSuggestRequest.clip_to_bounding_box:type_name -> w3w.v1.Square
+	11, // 14: w3w.v1.AutoSuggestRequest.clip_to_circle:type_name -> w3w.v1.Circle
+	10, // 15: w3w.v1.AutoSuggestResponse.suggestions:type_name -> w3w.v1.Suggestion
+	0,  // 16: w3w.v1.Line.start:type_name -> w3w.v1.Coordinates
+	0,  // 17: w3w.v1.Line.end:type_name -> w3w.v1.Coordinates
+	1,  // 18: w3w.v1.GridSectionRequest.bounding_box:type_name -> w3w.v1.Square
+	14, // 19: w3w.v1.GridSectionResponse.lines:type_name -> w3w.v1.Line
+	17, // 20: w3w.v1.AvailableLanguagesResponse.languages:type_name -> w3w.v1.Language
+	4,  // 21: w3w.v1.What3WordsService.ConvertToCoordinates:input_type -> w3w.v1.ConvertToCoordinatesRequest
+	6,  // 22: w3w.v1.What3WordsService.ConvertToWords:input_type -> w3w.v1.ConvertToWordsRequest
+	8,  // 23: w3w.v1.What3WordsService.BulkConvert:input_type -> w3w.v1.BulkConvertRequest
+	12, // 24: w3w.v1.What3WordsService.AutoSuggest:input_type -> w3w.v1.AutoSuggestRequest
+	15, // 25: w3w.v1.What3WordsService.GridSection:input_type -> w3w.v1.GridSectionRequest
+	18, // 26: w3w.v1.What3WordsService.AvailableLanguages:input_type -> w3w.v1.AvailableLanguagesRequest
+	5,  // 27: w3w.v1.What3WordsService.ConvertToCoordinates:output_type -> w3w.v1.ConvertToCoordinatesResponse
+	7,  // 28: w3w.v1.What3WordsService.ConvertToWords:output_type -> w3w.v1.ConvertToWordsResponse
+	9,  // 29: w3w.v1.What3WordsService.BulkConvert:output_type -> w3w.v1.BulkConvertResponse
+	13, // 30: w3w.v1.What3WordsService.AutoSuggest:output_type -> w3w.v1.AutoSuggestResponse
+	16, // 31: w3w.v1.What3WordsService.GridSection:output_type -> w3w.v1.GridSectionResponse
+	19, // 32: w3w.v1.What3WordsService.AvailableLanguages:output_type -> w3w.v1.AvailableLanguagesResponse
+	27, // [27:33] is the sub-list for method output_type
+	21, // [21:27] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_w3w_v1_w3w_proto_init() }
+func file_w3w_v1_w3w_proto_init() {
+	if File_w3w_v1_w3w_proto != nil {
+		return
+	}
+	file_w3w_v1_w3w_proto_msgTypes[8].OneofWrappers = []any{
+		(*BulkConvertRequest_ToCoordinates)(nil),
+		(*BulkConvertRequest_ToWords)(nil),
+	}
+	file_w3w_v1_w3w_proto_msgTypes[9].OneofWrappers = []any{
+		(*BulkConvertResponse_Result)(nil),
+		(*BulkConvertResponse_Error)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_w3w_v1_w3w_proto_rawDesc), len(file_w3w_v1_w3w_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_w3w_v1_w3w_proto_goTypes,
+		DependencyIndexes: file_w3w_v1_w3w_proto_depIdxs,
+		MessageInfos:      file_w3w_v1_w3w_proto_msgTypes,
+	}.Build()
+	File_w3w_v1_w3w_proto = out.File
+	file_w3w_v1_w3w_proto_goTypes = nil
+	file_w3w_v1_w3w_proto_depIdxs = nil
+}
@@ -0,0 +1,332 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: w3w/v1/w3w.proto
+
+package w3wpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	What3WordsService_ConvertToCoordinates_FullMethodName = "/w3w.v1.What3WordsService/ConvertToCoordinates"
+	What3WordsService_ConvertToWords_FullMethodName       = "/w3w.v1.What3WordsService/ConvertToWords"
+	What3WordsService_BulkConvert_FullMethodName          = "/w3w.v1.What3WordsService/BulkConvert"
+	What3WordsService_AutoSuggest_FullMethodName          = "/w3w.v1.What3WordsService/AutoSuggest"
+	What3WordsService_GridSection_FullMethodName          = "/w3w.v1.What3WordsService/GridSection"
+	What3WordsService_AvailableLanguages_FullMethodName   = "/w3w.v1.What3WordsService/AvailableLanguages"
+)
+
+// What3WordsServiceClient is the client API for What3WordsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// What3WordsService converts between 3 word addresses and coordinates,
+// suggests addresses and returns sections of the grid using the what3words
+// API.
+type What3WordsServiceClient interface {
+	// ConvertToCoordinates converts a 3 word address into coordinates.
+	ConvertToCoordinates(ctx context.Context, in *ConvertToCoordinatesRequest, opts ...grpc.CallOption) (*ConvertToCoordinatesResponse, error)
+	// ConvertToWords converts coordinates into a 3 word address.
+	ConvertToWords(ctx context.Context, in *ConvertToWordsRequest, opts ...grpc.CallOption) (*ConvertToWordsResponse, error)
+	// BulkConvert converts a stream of requests, returning a response per
+	// request as each completes. Responses may arrive out of order and are
+	// matched to requests by id. A failed conversion is reported in its
+	// response rather than ending the stream.
+	BulkConvert(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[BulkConvertRequest, BulkConvertResponse], error)
+	// AutoSuggest suggests 3 word addresses for partial or mistyped input.
+	AutoSuggest(ctx context.Context, in *AutoSuggestRequest, opts ...grpc.CallOption) (*AutoSuggestResponse, error)
+	// GridSection returns the lines of the grid within a bounding box.
+	GridSection(ctx context.Context, in *GridSectionRequest, opts ...grpc.CallOption) (*GridSectionResponse, error)
+	// AvailableLanguages lists the languages 3 word addresses are available in.
+	AvailableLanguages(ctx context.Context, in *AvailableLanguagesRequest, opts ...grpc.CallOption) (*AvailableLanguagesResponse, error)
+}
+
+type what3WordsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWhat3WordsServiceClient(cc grpc.ClientConnInterface) What3WordsServiceClient {
+	return &what3WordsServiceClient{cc}
+}
+
+func (c *what3WordsServiceClient) ConvertToCoordinates(ctx context.Context, in *ConvertToCoordinatesRequest, opts ...grpc.CallOption) (*ConvertToCoordinatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConvertToCoordinatesResponse)
+	err := c.cc.Invoke(ctx, What3WordsService_ConvertToCoordinates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *what3WordsServiceClient) ConvertToWords(ctx context.Context, in *ConvertToWordsRequest, opts ...grpc.CallOption) (*ConvertToWordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConvertToWordsResponse)
+	err := c.cc.Invoke(ctx, What3WordsService_ConvertToWords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *what3WordsServiceClient) BulkConvert(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[BulkConvertRequest, BulkConvertResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &What3WordsService_ServiceDesc.Streams[0], What3WordsService_BulkConvert_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BulkConvertRequest, BulkConvertResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type What3WordsService_BulkConvertClient = grpc.BidiStreamingClient[BulkConvertRequest, BulkConvertResponse]
+
+func (c *what3WordsServiceClient) AutoSuggest(ctx context.Context, in *AutoSuggestRequest, opts ...grpc.CallOption) (*AutoSuggestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AutoSuggestResponse)
+	err := c.cc.Invoke(ctx, What3WordsService_AutoSuggest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *what3WordsServiceClient) GridSection(ctx context.Context, in *GridSectionRequest, opts ...grpc.CallOption) (*GridSectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GridSectionResponse)
+	err := c.cc.Invoke(ctx, What3WordsService_GridSection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *what3WordsServiceClient) AvailableLanguages(ctx context.Context, in *AvailableLanguagesRequest, opts ...grpc.CallOption) (*AvailableLanguagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AvailableLanguagesResponse)
+	err := c.cc.Invoke(ctx, What3WordsService_AvailableLanguages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// What3WordsServiceServer is the server API for What3WordsService service.
+// All implementations must embed UnimplementedWhat3WordsServiceServer
+// for forward compatibility.
+//
+// What3WordsService converts between 3 word addresses and coordinates,
+// suggests addresses and returns sections of the grid using the what3words
+// API.
+type What3WordsServiceServer interface {
+	// ConvertToCoordinates converts a 3 word address into coordinates.
+	ConvertToCoordinates(context.Context, *ConvertToCoordinatesRequest) (*ConvertToCoordinatesResponse, error)
+	// ConvertToWords converts coordinates into a 3 word address.
+	ConvertToWords(context.Context, *ConvertToWordsRequest) (*ConvertToWordsResponse, error)
+	// BulkConvert converts a stream of requests, returning a response per
+	// request as each completes. Responses may arrive out of order and are
+	// matched to requests by id. A failed conversion is reported in its
+	// response rather than ending the stream.
+	BulkConvert(grpc.BidiStreamingServer[BulkConvertRequest, BulkConvertResponse]) error
+	// AutoSuggest suggests 3 word addresses for partial or mistyped input.
+	AutoSuggest(context.Context, *AutoSuggestRequest) (*AutoSuggestResponse, error)
+	// GridSection returns the lines of the grid within a bounding box.
+	GridSection(context.Context, *GridSectionRequest) (*GridSectionResponse, error)
+	// AvailableLanguages lists the languages 3 word addresses are available in.
+	AvailableLanguages(context.Context, *AvailableLanguagesRequest) (*AvailableLanguagesResponse, error)
+	mustEmbedUnimplementedWhat3WordsServiceServer()
+}
+
+// UnimplementedWhat3WordsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWhat3WordsServiceServer struct{}
+
+func (UnimplementedWhat3WordsServiceServer) ConvertToCoordinates(context.Context, *ConvertToCoordinatesRequest) (*ConvertToCoordinatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConvertToCoordinates not implemented")
+}
+func (UnimplementedWhat3WordsServiceServer) ConvertToWords(context.Context, *ConvertToWordsRequest) (*ConvertToWordsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConvertToWords not implemented")
+}
+func (UnimplementedWhat3WordsServiceServer) BulkConvert(grpc.BidiStreamingServer[BulkConvertRequest, BulkConvertResponse]) error {
+	return status.Error(codes.Unimplemented, "method BulkConvert not implemented")
+}
+func (UnimplementedWhat3WordsServiceServer) AutoSuggest(context.Context, *AutoSuggestRequest) (*AutoSuggestResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AutoSuggest not implemented")
+}
+func (UnimplementedWhat3WordsServiceServer) GridSection(context.Context, *GridSectionRequest) (*GridSectionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GridSection not implemented")
+}
+func (UnimplementedWhat3WordsServiceServer) AvailableLanguages(context.Context, *AvailableLanguagesRequest) (*AvailableLanguagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AvailableLanguages not implemented")
+}
+func (UnimplementedWhat3WordsServiceServer) mustEmbedUnimplementedWhat3WordsServiceServer() {}
+func (UnimplementedWhat3WordsServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeWhat3WordsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to What3WordsServiceServer will
+// result in compilation errors.
+type UnsafeWhat3WordsServiceServer interface {
+	mustEmbedUnimplementedWhat3WordsServiceServer()
+}
+
+func RegisterWhat3WordsServiceServer(s grpc.ServiceRegistrar, srv What3WordsServiceServer) {
+	// If the following call panics, it indicates UnimplementedWhat3WordsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&What3WordsService_ServiceDesc, srv)
+}
+
+func _What3WordsService_ConvertToCoordinates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertToCoordinatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(What3WordsServiceServer).ConvertToCoordinates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: What3WordsService_ConvertToCoordinates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(What3WordsServiceServer).ConvertToCoordinates(ctx, req.(*ConvertToCoordinatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _What3WordsService_ConvertToWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertToWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(What3WordsServiceServer).ConvertToWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: What3WordsService_ConvertToWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(What3WordsServiceServer).ConvertToWords(ctx, req.(*ConvertToWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _What3WordsService_BulkConvert_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(What3WordsServiceServer).BulkConvert(&grpc.GenericServerStream[BulkConvertRequest, BulkConvertResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type What3WordsService_BulkConvertServer = grpc.BidiStreamingServer[BulkConvertRequest, BulkConvertResponse]
+
+func _What3WordsService_AutoSuggest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AutoSuggestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(What3WordsServiceServer).AutoSuggest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: What3WordsService_AutoSuggest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(What3WordsServiceServer).AutoSuggest(ctx, req.(*AutoSuggestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _What3WordsService_GridSection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GridSectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(What3WordsServiceServer).GridSection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: What3WordsService_GridSection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(What3WordsServiceServer).GridSection(ctx, req.(*GridSectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _What3WordsService_AvailableLanguages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AvailableLanguagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(What3WordsServiceServer).AvailableLanguages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: What3WordsService_AvailableLanguages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(What3WordsServiceServer).AvailableLanguages(ctx, req.(*AvailableLanguagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// What3WordsService_ServiceDesc is the grpc.ServiceDesc for What3WordsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var What3WordsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "w3w.v1.What3WordsService",
+	HandlerType: (*What3WordsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ConvertToCoordinates",
+			Handler:    _What3WordsService_ConvertToCoordinates_Handler,
+		},
+		{
+			MethodName: "ConvertToWords",
+			Handler:    _What3WordsService_ConvertToWords_Handler,
+		},
+		{
+			MethodName: "AutoSuggest",
+			Handler:    _What3WordsService_AutoSuggest_Handler,
+		},
+		{
+			MethodName: "GridSection",
+			Handler:    _What3WordsService_GridSection_Handler,
+		},
+		{
+			MethodName: "AvailableLanguages",
+			Handler:    _What3WordsService_AvailableLanguages_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BulkConvert",
+			Handler:       _What3WordsService_BulkConvert_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "w3w/v1/w3w.proto",
+}
@@ -0,0 +1,70 @@
+//go:build e2e
+// +build e2e
+
+package e2e_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jonnypillar/what3words/pkg/w3w"
+	"github.com/jonnypillar/what3words/pkg/w3w/w3wtest"
+)
+
+// W3W_E2E_MODE selects how the e2e tests reach the API
+const (
+	// modeReplay answers requests from the fixtures in testdata, without a
+	// key or network access
+	modeReplay = "replay"
+	// modeRecord calls the live API and rewrites the fixtures in testdata
+	modeRecord = "record"
+	// modeLive calls the live API without touching the fixtures
+	modeLive = "live"
+)
+
+// newClient returns a client for the mode set by W3W_E2E_MODE, replay by
+// default, using the fixture file in testdata named fixture
+func newClient(t *testing.T, fixture string) *w3w.Client {
+	t.Helper()
+
+	path := filepath.Join("testdata", fixture+".json")
+	apiKey := os.Getenv("W3W_INTEGRATION_API_KEY")
+
+	var opts []w3w.Option
+
+	switch mode := os.Getenv("W3W_E2E_MODE"); mode {
+	case "", modeReplay:
+		rep, err := w3wtest.NewReplayer(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apiKey = "replay"
+		opts = append(opts, w3w.WithHTTPClient(&http.Client{Transport: rep}))
+	case modeRecord:
+		if apiKey == "" {
+			t.Fatal("W3W_INTEGRATION_API_KEY is required to record fixtures")
+		}
+
+		rec := w3wtest.NewRecorder(path, nil)
+		t.Cleanup(func() {
+			if err := rec.Save(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		opts = append(opts, w3w.WithHTTPClient(&http.Client{Transport: rec}))
+	case modeLive:
+	default:
+		t.Fatalf("unknown W3W_E2E_MODE %q", mode)
+	}
+
+	c, err := w3w.New(apiKey, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
@@ -1,9 +1,9 @@
+//go:build e2e
 // +build e2e
 
 package e2e_test
 
 import (
-	"os"
 	"testing"
 
 	"github.com/jonnypillar/what3words/pkg/w3w"
@@ -82,11 +82,10 @@ func TestE2EWords(t *testing.T) {
 			},
 		},
 	}
+	c := newClient(t, "words")
+
 	for _, tt := range testCases {
 		t.Run(tt.desc, func(t *testing.T) {
-			apiKey := os.Getenv("W3W_INTEGRATION_API_KEY")
-			c, _ := w3w.New(apiKey)
-
 			res, err := c.GetWords(tt.words, tt.opts)
 
 			if tt.expectedErr != nil {